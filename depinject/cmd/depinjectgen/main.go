@@ -0,0 +1,39 @@
+// Command depinjectgen generates reflection-free wiring code for depinject contexts.
+//
+// Usage in a package, with a `Register(context *depinject.Context)` registration function:
+//
+//	//go:generate go run github.com/deverdeb/bvmgo-util/depinject/cmd/depinjectgen
+//
+// The generated file contains a `Wiring` structure (`NewWiring()`, `Start()`, `Stop()` and elements getters).
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/deverdeb/bvmgo-util/depinject/generator"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	options := generator.DefaultOptions()
+	flag.StringVar(&options.Function, "func", options.Function, "name of the registration function")
+	flag.StringVar(&options.TypeName, "type", options.TypeName, "name of the generated wiring structure")
+	flag.StringVar(&options.Output, "output", options.Output, "name of the generated file")
+	dir := flag.String("dir", ".", "directory of the package")
+	flag.Parse()
+
+	source, err := generator.Generate(*dir, options)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "depinjectgen: %v\n", err)
+		os.Exit(1)
+	}
+	output := options.Output
+	if !filepath.IsAbs(output) {
+		output = filepath.Join(*dir, output)
+	}
+	if err = os.WriteFile(output, source, 0644); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "depinjectgen: failed to write '%s' file: %v\n", output, err)
+		os.Exit(1)
+	}
+}
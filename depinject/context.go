@@ -7,18 +7,35 @@ import (
 	"strings"
 )
 
+// InjectTag is the tag of fields to inject.
+// Tag value is the dependency name. Dependency is searched by type if tag value is empty.
 const InjectTag string = "inject"
 
+// InjectNestedTag is the tag of nested structure fields whose fields must be injected.
+// Fields of embedded structures are always injected, without tag.
+const InjectNestedTag string = "injectNested"
+
 // Context is a container for application structures.
 // It injects dependencies by type or name.
+//
+// A context must not be copied after its first use: copies share the elements index and the event bus,
+// but not the elements list. Use a pointer to the context (example: `&depinject.GlobalContext`).
 type Context struct {
 	// Is context started ?
 	started bool
 	// elements contains all context elements.
 	elements []*elementInformation
+	// index contains all context elements, indexed by name and type.
+	index *elementIndex
 	// initializedElements contains all initialized elements.
 	// Elements are ordered by initialization order.
 	initializedElements []*elementInformation
+	// collectErrors is true if Start method tries every element and returns all failures.
+	collectErrors bool
+	// startFailures contains the failures found during start (only when errors are collected).
+	startFailures *startFailures
+	// events is the context event bus.
+	events *EventBus
 }
 
 // CreateContext build an empty context instance.
@@ -26,8 +43,20 @@ func CreateContext() Context {
 	return Context{
 		started:             false,
 		elements:            make([]*elementInformation, 0),
+		index:               newElementIndex(),
 		initializedElements: make([]*elementInformation, 0),
+		events:              NewEventBus(),
+	}
+}
+
+// Events returns the context event bus.
+// Initialized elements implementing EventListener interface are subscribed to this event bus.
+// Other elements are not subscribed.
+func (context *Context) Events() *EventBus {
+	if context.events == nil {
+		context.events = NewEventBus()
 	}
+	return context.events
 }
 
 // GlobalContext is a default application global context.
@@ -47,28 +76,111 @@ func (context *Context) AddWithName(element interface{}, name string) error {
 	if element == nil {
 		return errors.New("context does not support nil element")
 	}
-	// check if not exists another element with same name and type
-	typeOfElement := reflect.TypeOf(element)
-	alreadyExistElements := context.getElementsByNameAndType(name, typeOfElement)
-	if len(alreadyExistElements) > 0 {
-		return errors.New("cannot add '%s' element, another element exists with same name and type: %s",
-			name, alreadyExistElements[0].ToString())
-	}
-	information := &elementInformation{
-		eltType: typeOfElement,
+	return context.addElement(&elementInformation{
+		eltType: reflect.TypeOf(element),
 		name:    name,
 		status:  Uninitialized,
 		value:   element,
+	})
+}
+
+// AddAs add an element to context, exposed only as the interface types for injections by type.
+// Interface types are defined by nil pointers of interfaces.
+// Example:
+//
+//	err := context.AddAs(&MemoryStore{}, (*Store)(nil), (*Cache)(nil))
+func (context *Context) AddAs(element interface{}, interfaces ...interface{}) error {
+	if element == nil {
+		return errors.New("context does not support nil element")
+	}
+	return context.AddWithNameAs(element, introsp.TypeName(reflect.TypeOf(element)), interfaces...)
+}
+
+// AddWithNameAs add an element with a name to context, exposed only as the interface types for injections by type.
+// Interface types are defined by nil pointers of interfaces (see AddAs).
+// Method returns error if element does not implement interfaces or if another element exists with same name and type.
+func (context *Context) AddWithNameAs(element interface{}, name string, interfaces ...interface{}) error {
+	if element == nil {
+		return errors.New("context does not support nil element")
+	}
+	if len(interfaces) == 0 {
+		return errors.New("cannot add '%s' element, require at least one interface type", name)
+	}
+	typeOfElement := reflect.TypeOf(element)
+	exposedTypes := make([]reflect.Type, 0, len(interfaces))
+	for _, iface := range interfaces {
+		interfaceType := reflect.TypeOf(iface)
+		if interfaceType == nil || interfaceType.Kind() != reflect.Ptr || interfaceType.Elem().Kind() != reflect.Interface {
+			return errors.New("cannot add '%s' element, interface type must be defined by a nil pointer of interface "+
+				"(example: `(*MyInterface)(nil)`), unsupported type %s", name, introsp.TypeName(interfaceType))
+		}
+		interfaceType = interfaceType.Elem()
+		if !typeOfElement.Implements(interfaceType) {
+			return errors.New("cannot add '%s' element, type %s does not implement %s interface",
+				name, introsp.TypeName(typeOfElement), introsp.TypeName(interfaceType))
+		}
+		exposedTypes = append(exposedTypes, interfaceType)
+	}
+	return context.addElement(&elementInformation{
+		eltType:      typeOfElement,
+		name:         name,
+		status:       Uninitialized,
+		value:        element,
+		exposedTypes: exposedTypes,
+	})
+}
+
+// addElement adds an element to context.
+// Method returns error if another element exists with same name and type.
+func (context *Context) addElement(information *elementInformation) error {
+	// check if not exists another element with same name and type
+	exposedTypes := information.exposedTypes
+	if exposedTypes == nil {
+		exposedTypes = []reflect.Type{information.eltType}
+	}
+	for _, exposedType := range exposedTypes {
+		alreadyExistElements := context.getElementsByNameAndType(information.name, exposedType)
+		if len(alreadyExistElements) > 0 {
+			return errors.New("cannot add '%s' element, another element exists with same name and type: %s",
+				information.name, alreadyExistElements[0].ToString())
+		}
 	}
 	context.elements = append(context.elements, information)
+	context.elementIndex().add(information)
 	if context.started {
 		return context.initializeElement(information)
 	}
 	return nil
 }
 
+// elementIndex returns the index of context elements.
+func (context *Context) elementIndex() *elementIndex {
+	if context.index == nil {
+		context.index = newElementIndex()
+		for _, element := range context.elements {
+			context.index.add(element)
+		}
+	}
+	return context.index
+}
+
+// CollectErrors returns true if Start method tries every element and collects every failure.
+func (context *Context) CollectErrors() bool {
+	return context.collectErrors
+}
+
+// SetCollectErrors defines if Start method tries every element and collects every failure.
+// By default, Start method stops at the first failure.
+// When errors are collected, Start method returns a StartError with every independent failure.
+func (context *Context) SetCollectErrors(collect bool) {
+	context.collectErrors = collect
+}
+
 // Start inject dependencies and call `AfterInject()` methods of context structures.
 func (context *Context) Start() error {
+	if context.collectErrors {
+		return context.startAndCollectErrors()
+	}
 	// Inject dependencies
 	for _, element := range context.elements {
 		err := context.initializeElement(element)
@@ -78,13 +190,39 @@ func (context *Context) Start() error {
 				"error during '%s' element initialization", element.name)
 		}
 	}
-	// Context is started
-	context.started = true
+	context.markStarted()
+	return nil
+}
+
+// startAndCollectErrors inject dependencies and call `AfterInject()` methods of every context structures.
+// Method returns a StartError with all failures.
+func (context *Context) startAndCollectErrors() error {
+	context.startFailures = newStartFailures()
+	defer func() { context.startFailures = nil }()
+	// Inject dependencies
+	for _, element := range context.elements {
+		// errors are collected in context.startFailures
+		_ = context.initializeElement(element)
+	}
+	if len(context.startFailures.failures) > 0 {
+		context.Stop()
+		return &StartError{failures: context.startFailures.failures}
+	}
+	context.markStarted()
 	return nil
 }
 
+// markStarted marks context as started and publishes ContextStarted event.
+func (context *Context) markStarted() {
+	context.started = true
+	context.Events().handleError(Publish(context.Events(), ContextStarted{Context: context}))
+}
+
 // Stop call `Release()` methods of context structures.
 func (context *Context) Stop() {
+	if context.started {
+		context.Events().handleError(Publish(context.Events(), ContextStopping{Context: context}))
+	}
 	for _, element := range context.initializedElements {
 		context.releaseElement(element)
 	}
@@ -93,6 +231,9 @@ func (context *Context) Stop() {
 }
 
 func (context *Context) initializeElement(information *elementInformation) error {
+	if context.startFailures != nil && context.startFailures.isFailed(information) {
+		return errors.NewWithCause(errFailedElement, "cannot initialized '%s' element", information.ToString())
+	}
 	if information.status == InInitialization {
 		return errors.New("failed to initialized '%s' element, potential dependency loop", information.ToString())
 	}
@@ -101,75 +242,107 @@ func (context *Context) initializeElement(information *elementInformation) error
 		err := context.injectDependencies(information)
 		if err != nil {
 			context.releaseElement(information)
-			return errors.NewWithCause(err, "failed to inject dependencies of '%s' element", information.ToString())
+			return context.failElement(information,
+				errors.NewWithCause(err, "failed to inject dependencies of '%s' element", information.ToString()))
 		}
 		// Execute process after injection
 		err = context.callAfterInject(information)
 		if err != nil {
 			context.releaseElement(information)
-			return errors.NewWithCause(err, "failed to initialized '%s' element after dependencies injection", information.ToString())
+			if context.startFailures != nil {
+				context.startFailures.add(information, "", err)
+			}
+			return context.failElement(information,
+				errors.NewWithCause(err, "failed to initialized '%s' element after dependencies injection", information.ToString()))
+		}
+		// Subscribe element to context events, if element is an event listener
+		if isEventListener(information.value) {
+			information.subscriber = context.Events().subscribe(information.value)
 		}
 	}
 	return nil
 }
 
+// failElement returns the initialization error of the element.
+// When errors are collected, element is marked as failed and the error is replaced: failures are already collected.
+func (context *Context) failElement(information *elementInformation, err error) error {
+	if context.startFailures == nil {
+		return err
+	}
+	context.startFailures.markFailed(information)
+	return errors.NewWithCause(errFailedElement, "failed to initialized '%s' element", information.ToString())
+}
+
 // injectDependencies injects dependencies from context to element.
 func (context *Context) injectDependencies(information *elementInformation) error {
 	information.status = InInitialization
-	eltStructType := findStructType(information.eltType)
-	if eltStructType == nil {
+	if findStructType(information.eltType) == nil {
 		// element is not a structure: no injections
 		information.status = Initialized
 		return nil
 	}
-	// loop on element fields to find fields with injection tag ("inject")
-	fieldsNumber := eltStructType.NumField()
-	for fieldIndex := 0; fieldIndex < fieldsNumber; fieldIndex++ {
-		field := eltStructType.Field(fieldIndex)
-		fieldType := findNoPointerType(field.Type)
-		tagValue, ok := field.Tag.Lookup(InjectTag)
-		if ok {
-			dependencyName := strings.TrimSpace(tagValue)
-			var dependency *elementInformation
-			var err error
-			if dependencyName == "" {
-				dependency, err = context.getElementByType(fieldType)
-				if err != nil {
-					return errors.NewWithCause(err, "failed to find '%s' dependency (by type: %s) of '%s' element",
-						field.Name, introsp.TypeName(fieldType), information.ToString())
-				} else if dependency == nil {
-					return errors.New("missing '%s' dependency (by type: %s) of '%s' element",
-						field.Name, introsp.TypeName(fieldType), information.ToString())
-				}
-			} else {
-				dependency, err = context.getElementByName(dependencyName)
-				if err != nil {
-					return errors.NewWithCause(err, "failed to find '%s' dependency (by name: %s) of '%s' element",
-						field.Name, dependencyName, information.ToString())
-				} else if dependency == nil {
-					return errors.New("missing '%s' dependency (by name: %s) of '%s' element",
-						field.Name, dependencyName, information.ToString())
-				}
-			}
-			if dependency.status != Initialized {
-				err = context.initializeElement(dependency)
-				if err != nil {
-					return errors.NewWithCause(err, "failed to initialized '%s' dependency of '%s' element",
-						field.Name, information.ToString())
-				}
-			}
-			err = introsp.SetAttribute(information.value, field.Name, dependency.value)
-			if err != nil {
-				return errors.NewWithCause(err, "failed to initialized '%s' dependency of '%s' element, field cannot be set",
-					field.Name, information.ToString())
+	// loop on element fields (and nested structures fields) to find fields with injection tag ("inject")
+	failed := false
+	for _, injection := range findInjectionFields(information.eltType) {
+		err := context.injectDependency(information, injection)
+		if err != nil {
+			if context.startFailures == nil {
+				return err
 			}
+			// collect failure and continue with next field
+			context.startFailures.add(information, injection.path, err)
+			failed = true
 		}
 	}
+	if failed {
+		return errFailedElement
+	}
 	context.initializedElements = append(context.initializedElements, information)
 	information.status = Initialized
 	return nil
 }
 
+// injectDependency injects a dependency from context to element field.
+func (context *Context) injectDependency(information *elementInformation, injection injectionField) error {
+	field := injection.field
+	fieldType := findNoPointerType(field.Type)
+	dependencyName := strings.TrimSpace(field.Tag.Get(InjectTag))
+	var dependency *elementInformation
+	var err error
+	if dependencyName == "" {
+		dependency, err = context.getElementByType(fieldType)
+		if err != nil {
+			return errors.NewWithCause(err, "failed to find '%s' dependency (by type: %s) of '%s' element",
+				injection.path, introsp.TypeName(fieldType), information.ToString())
+		} else if dependency == nil {
+			return errors.New("missing '%s' dependency (by type: %s) of '%s' element",
+				injection.path, introsp.TypeName(fieldType), information.ToString())
+		}
+	} else {
+		dependency, err = context.getElementByName(dependencyName)
+		if err != nil {
+			return errors.NewWithCause(err, "failed to find '%s' dependency (by name: %s) of '%s' element",
+				injection.path, dependencyName, information.ToString())
+		} else if dependency == nil {
+			return errors.New("missing '%s' dependency (by name: %s) of '%s' element",
+				injection.path, dependencyName, information.ToString())
+		}
+	}
+	if dependency.status != Initialized {
+		err = context.initializeElement(dependency)
+		if err != nil {
+			return errors.NewWithCause(err, "failed to initialized '%s' dependency of '%s' element",
+				injection.path, information.ToString())
+		}
+	}
+	err = introsp.SetAttributePath(information.value, injection.path, dependency.value)
+	if err != nil {
+		return errors.NewWithCause(err, "failed to initialized '%s' dependency of '%s' element, field cannot be set",
+			injection.path, information.ToString())
+	}
+	return nil
+}
+
 // callAfterInject finalize element initialization by call Initializable.AfterInject() method if exists.
 func (context *Context) callAfterInject(information *elementInformation) error {
 	if information == nil || information.value == nil || information.status != Initialized {
@@ -185,6 +358,10 @@ func (context *Context) callAfterInject(information *elementInformation) error {
 // releaseElement release a contexte element.
 // Call Releasable.Release() method if element value implements Releasable interface.
 func (context *Context) releaseElement(information *elementInformation) {
+	if information.subscriber != nil {
+		context.Events().unsubscribe(information.subscriber)
+		information.subscriber = nil
+	}
 	context.callReleaseMethod(information)
 	context.removeDependencies(information)
 }
@@ -202,16 +379,8 @@ func (context *Context) callReleaseMethod(information *elementInformation) {
 
 // removeDependencies removes all element dependencies
 func (context *Context) removeDependencies(information *elementInformation) {
-	eltStructType := findStructType(information.eltType)
-	if eltStructType != nil {
-		fieldsNumber := eltStructType.NumField()
-		for fieldIndex := 0; fieldIndex < fieldsNumber; fieldIndex++ {
-			field := eltStructType.Field(fieldIndex)
-			_, ok := field.Tag.Lookup(InjectTag)
-			if ok {
-				_ = introsp.SetAttribute(information.value, field.Name, nil)
-			}
-		}
+	for _, injection := range findInjectionFields(information.eltType) {
+		_ = introsp.SetAttributePath(information.value, injection.path, nil)
 	}
 	// FIXME ...
 	// ...> context.initializedElements = remove(context.initializedElements, information)
@@ -222,14 +391,7 @@ func (context *Context) getElementByType(eltType reflect.Type) (*elementInformat
 	if eltType == nil {
 		return nil, nil
 	}
-	finds := make([]*elementInformation, 0)
-	for _, element := range context.elements {
-		if element.eltType == eltType ||
-			element.eltType.AssignableTo(eltType) ||
-			(element.eltType.Elem() != nil && element.eltType.Elem().AssignableTo(eltType)) {
-			finds = append(finds, element)
-		}
-	}
+	finds := context.elementIndex().findByType(eltType)
 	if len(finds) == 1 {
 		return finds[0], nil
 	} else if len(finds) == 0 {
@@ -286,13 +448,7 @@ func (context *Context) getElementByName(name string) (*elementInformation, erro
 // getElementsByName search all elements with the parameter name.
 // Method empty slice if no element is found.
 func (context *Context) getElementsByName(name string) []*elementInformation {
-	finds := make([]*elementInformation, 0)
-	for _, element := range context.elements {
-		if element.name == name {
-			finds = append(finds, element)
-		}
-	}
-	return finds
+	return context.elementIndex().findByName(name)
 }
 
 // getElementsByNameAndType search all elements with the parameter name and the parameter type.
@@ -301,9 +457,7 @@ func (context *Context) getElementsByNameAndType(name string, eltType reflect.Ty
 	finds := make([]*elementInformation, 0)
 	findsByName := context.getElementsByName(name)
 	for _, element := range findsByName {
-		if element.eltType == eltType ||
-			element.eltType.AssignableTo(eltType) ||
-			(element.eltType.Elem() != nil && element.eltType.Elem().AssignableTo(eltType)) {
+		if element.matchType(eltType) {
 			finds = append(finds, element)
 		}
 	}
@@ -2,6 +2,7 @@ package depinject
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -206,3 +207,87 @@ func TestContext_Stop_ReleaseInjections(t *testing.T) {
 		t.Errorf("obj3.Obj3Struct2 = %v, want = %v", obj4.Obj3Struct2, nil)
 	}
 }
+
+type structInjectBase struct {
+	Logger *structInjectTest1 `inject:"obj1"`
+}
+
+type structInjectNested struct {
+	Service *structInjectTest2 `inject:""`
+}
+
+type structInjectTest4 struct {
+	structInjectBase
+	Nested    structInjectNested  `injectNested:""`
+	PtrNested *structInjectNested `injectNested:""`
+	Ignored   structInjectNested
+}
+
+func TestContext_Start_WithInjectionInNestedStructures(t *testing.T) {
+	testContext := CreateContext()
+	obj1 := &structInjectTest1{}
+	_ = testContext.AddWithName(obj1, "obj1")
+	obj2 := &structInjectTest2{}
+	_ = testContext.Add(obj2)
+	obj3 := &structInjectTest4{PtrNested: &structInjectNested{}}
+	_ = testContext.Add(obj3)
+	err := testContext.Start()
+	if err != nil {
+		t.Errorf("cannot start context, error found: %v", err)
+	}
+	// Verify dependencies injections
+	if obj3.Logger != obj1 {
+		t.Errorf("obj3.Logger = %v, want = %v", obj3.Logger, obj1)
+	}
+	if obj3.Nested.Service != obj2 {
+		t.Errorf("obj3.Nested.Service = %v, want = %v", obj3.Nested.Service, obj2)
+	}
+	if obj3.PtrNested.Service != obj2 {
+		t.Errorf("obj3.PtrNested.Service = %v, want = %v", obj3.PtrNested.Service, obj2)
+	}
+	if obj3.Ignored.Service != nil {
+		t.Errorf("obj3.Ignored.Service = %v, want = %v", obj3.Ignored.Service, nil)
+	}
+	// Verify dependencies release
+	testContext.Stop()
+	if obj3.Logger != nil {
+		t.Errorf("obj3.Logger = %v, want = %v", obj3.Logger, nil)
+	}
+	if obj3.Nested.Service != nil {
+		t.Errorf("obj3.Nested.Service = %v, want = %v", obj3.Nested.Service, nil)
+	}
+}
+
+func TestContext_Start_MissingNestedDependency(t *testing.T) {
+	testContext := CreateContext()
+	obj2 := &structInjectTest2{}
+	_ = testContext.Add(obj2)
+	_ = testContext.Add(&structInjectTest4{PtrNested: &structInjectNested{}})
+	err := testContext.Start()
+	if err == nil || !strings.Contains(err.Error(), "missing 'structInjectBase.Logger' dependency") {
+		t.Errorf("Error() = %v, want contains \"missing 'structInjectBase.Logger' dependency\"", err)
+	}
+}
+
+func TestContext_Start_NilNestedPointer(t *testing.T) {
+	testContext := CreateContext()
+	_ = testContext.AddWithName(&structInjectTest1{}, "obj1")
+	_ = testContext.Add(&structInjectTest2{})
+	_ = testContext.Add(&structInjectTest4{})
+	err := testContext.Start()
+	if err == nil || !strings.Contains(err.Error(), "'PtrNested.Service' dependency") {
+		t.Errorf("Error() = %v, want contains \"'PtrNested.Service' dependency\"", err)
+	}
+}
+
+type structInjectRecursive struct {
+	Next     *structInjectRecursive `injectNested:""`
+	Instance *structInjectTest2     `inject:""`
+}
+
+func Test_findInjectionFields_RecursiveStructure(t *testing.T) {
+	fields := findInjectionFields(reflect.TypeOf(&structInjectRecursive{}))
+	if len(fields) != 1 || fields[0].path != "Instance" {
+		t.Errorf("findInjectionFields() = %v, want only \"Instance\" field", fields)
+	}
+}
@@ -399,3 +399,137 @@ func TestContext_Stop(t *testing.T) {
 		t.Errorf("obj2.release = %v, want = %v", obj2.release, true)
 	}
 }
+
+type interfaceContextTest2 interface {
+	Method2()
+}
+
+type structContextTest2 struct {
+	structContextTest
+}
+
+func (test *structContextTest2) Method2() {
+}
+
+func TestContext_AddAs(t *testing.T) {
+	testContext := CreateContext()
+	obj1 := &structContextTest2{}
+	if err := testContext.AddAs(obj1, (*interfaceContextTest2)(nil)); err != nil {
+		t.Errorf("Error() = %v, want no error", err)
+	}
+	obj2 := &structContextTest{}
+	if err := testContext.Add(obj2); err != nil {
+		t.Errorf("Error() = %v, want no error", err)
+	}
+	// obj1 is only exposed as interfaceContextTest2
+	result, err := testContext.GetByType(reflect.TypeOf((*interfaceContextTest2)(nil)).Elem())
+	if err != nil {
+		t.Errorf("Error() = %v, want no error", err)
+	}
+	if result != obj1 {
+		t.Errorf("Result = %v, want = %v", result, obj1)
+	}
+	// obj1 implements interfaceContextTest but it is not exposed as interfaceContextTest
+	result, err = testContext.GetByType(reflect.TypeOf((*interfaceContextTest)(nil)).Elem())
+	if err != nil {
+		t.Errorf("Error() = %v, want no error", err)
+	}
+	if result != obj2 {
+		t.Errorf("Result = %v, want = %v", result, obj2)
+	}
+	// by name, element is found
+	result, err = testContext.GetByName("*structContextTest2")
+	if err != nil {
+		t.Errorf("Error() = %v, want no error", err)
+	}
+	if result != obj1 {
+		t.Errorf("Result = %v, want = %v", result, obj1)
+	}
+}
+
+func TestContext_AddAs_UpdateTypeIndex(t *testing.T) {
+	testContext := CreateContext()
+	interfaceType := reflect.TypeOf((*interfaceContextTest2)(nil)).Elem()
+	if _, err := testContext.GetByType(interfaceType); err == nil {
+		t.Errorf("Error() = %v, want not found element error", err)
+	}
+	obj1 := &structContextTest2{}
+	_ = testContext.AddWithNameAs(obj1, "obj1", (*interfaceContextTest2)(nil))
+	result, err := testContext.GetByType(interfaceType)
+	if err != nil {
+		t.Errorf("Error() = %v, want no error", err)
+	}
+	if result != obj1 {
+		t.Errorf("Result = %v, want = %v", result, obj1)
+	}
+}
+
+func TestElementIndex_ReturnsCopies(t *testing.T) {
+	index := newElementIndex()
+	obj := &structContextTest2{}
+	index.add(&elementInformation{eltType: reflect.TypeOf(obj), name: "obj", value: obj,
+		exposedTypes: []reflect.Type{reflect.TypeOf((*interfaceContextTest2)(nil)).Elem()}})
+	interfaceType := reflect.TypeOf((*interfaceContextTest2)(nil)).Elem()
+	index.findByName("obj")[0] = nil
+	index.findByType(interfaceType)[0] = nil
+	if finds := index.findByName("obj"); len(finds) != 1 || finds[0] == nil {
+		t.Errorf("findByName() = %v, want index not modified", finds)
+	}
+	if finds := index.findByType(interfaceType); len(finds) != 1 || finds[0] == nil {
+		t.Errorf("findByType() = %v, want index not modified", finds)
+	}
+}
+
+func TestContext_AddAs_Errors(t *testing.T) {
+	testContext := CreateContext()
+	obj := &structContextTest{}
+	tests := []struct {
+		name       string
+		interfaces []interface{}
+		want       string
+	}{
+		{name: "no interface", interfaces: nil, want: "require at least one interface type"},
+		{name: "not an interface", interfaces: []interface{}{obj}, want: "must be defined by a nil pointer of interface"},
+		{name: "not implemented", interfaces: []interface{}{(*interfaceContextTest2)(nil)}, want: "does not implement interfaceContextTest2 interface"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := testContext.AddAs(obj, tt.interfaces...)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Error() = %v, want contains \"%v\"", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestContext_Start_WithInjectionOfInterface(t *testing.T) {
+	testContext := CreateContext()
+	obj1 := &structContextTest2{}
+	_ = testContext.AddAs(obj1, (*interfaceContextTest2)(nil))
+	_ = testContext.AddWithName(123, "intValue")
+	obj2 := &struct {
+		Field interfaceContextTest2 `inject:""`
+	}{}
+	_ = testContext.Add(obj2)
+	if err := testContext.Start(); err != nil {
+		t.Errorf("cannot start context, error found: %v", err)
+	}
+	defer testContext.Stop()
+	if obj2.Field != obj1 {
+		t.Errorf("obj2.Field = %v, want = %v", obj2.Field, obj1)
+	}
+}
+
+func BenchmarkContext_GetByType(b *testing.B) {
+	testContext := CreateContext()
+	for index := 0; index < 1000; index++ {
+		_ = testContext.AddWithName(index, fmt.Sprintf("value%d", index))
+	}
+	obj := &structContextTest2{}
+	_ = testContext.AddAs(obj, (*interfaceContextTest2)(nil))
+	interfaceType := reflect.TypeOf((*interfaceContextTest2)(nil)).Elem()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = testContext.GetByType(interfaceType)
+	}
+}
@@ -40,8 +40,28 @@ type elementInformation struct {
 	status elementStatus
 	// value is the element value
 	value interface{}
+	// exposedTypes contains the types of element for injections by type.
+	// If it is nil, element is exposed as all types assignable from element type.
+	exposedTypes []reflect.Type
+	// subscriber is the element subscription to context events. It is nil if element is not initialized.
+	subscriber *eventSubscriber
 }
 
 func (element *elementInformation) ToString() string {
 	return fmt.Sprintf("[type=%s, name='%s', status=%s]", element.eltType.Name(), element.name, element.status.ToString())
 }
+
+// matchType returns true if element can be injected in a variable of the type.
+func (element *elementInformation) matchType(eltType reflect.Type) bool {
+	if element.exposedTypes != nil {
+		for _, exposedType := range element.exposedTypes {
+			if exposedType == eltType {
+				return true
+			}
+		}
+		return false
+	}
+	return element.eltType == eltType ||
+		element.eltType.AssignableTo(eltType) ||
+		(element.eltType.Kind() == reflect.Ptr && element.eltType.Elem().AssignableTo(eltType))
+}
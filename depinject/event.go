@@ -0,0 +1,214 @@
+package depinject
+
+import (
+	"fmt"
+	"github.com/deverdeb/bvmgo-util/errors"
+	"github.com/deverdeb/bvmgo-util/introsp"
+	"log"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// EventListener interface receives the events of E type published in an EventBus.
+// Context elements implementing EventListener are subscribed to context event bus when they are injected,
+// and unsubscribed when they are released.
+type EventListener[E any] interface {
+	// OnEvent is calling when an event is published.
+	OnEvent(event E)
+}
+
+// EventListenerFunc is a function adapter for EventListener interface.
+type EventListenerFunc[E any] func(event E)
+
+// OnEvent calls the function.
+func (listener EventListenerFunc[E]) OnEvent(event E) {
+	listener(event)
+}
+
+// ContextStarted is the event published when context is started.
+type ContextStarted struct {
+	// Context is the started context.
+	Context *Context
+}
+
+// ContextStopping is the event published when context is stopping, before elements release.
+type ContextStopping struct {
+	// Context is the stopping context.
+	Context *Context
+}
+
+// EventBus delivers published events to subscribed listeners.
+type EventBus struct {
+	// mutex protects subscribers.
+	mutex sync.RWMutex
+	// subscribers contains the subscribed listeners, in subscription order.
+	subscribers []*eventSubscriber
+	// errorHandler receives listeners errors of asynchronous deliveries and context events.
+	errorHandler func(err error)
+	// deliveries is used to wait asynchronous deliveries.
+	deliveries sync.WaitGroup
+}
+
+// eventSubscriber is a subscribed listener.
+type eventSubscriber struct {
+	// listener is the listener value. It receives events of E type if it implements EventListener[E].
+	listener interface{}
+}
+
+// NewEventBus creates an event bus without subscriber.
+// Errors of asynchronous deliveries are written with the standard logger (see log.Printf).
+func NewEventBus() *EventBus {
+	return &EventBus{
+		subscribers: make([]*eventSubscriber, 0),
+		errorHandler: func(err error) {
+			log.Printf("failed to deliver event: %+v", err)
+		},
+	}
+}
+
+// SetErrorHandler sets the function which receives listeners errors of asynchronous deliveries
+// and of context events (see ContextStarted and ContextStopping).
+func (bus *EventBus) SetErrorHandler(handler func(err error)) {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+	bus.errorHandler = handler
+}
+
+// Subscribe adds a listener to event bus.
+// Listener receives events of E type if it implements EventListener[E] (see also EventListenerFunc).
+// The returned function unsubscribes the listener.
+func (bus *EventBus) Subscribe(listener interface{}) (unsubscribe func()) {
+	subscriber := bus.subscribe(listener)
+	return func() {
+		bus.unsubscribe(subscriber)
+	}
+}
+
+// Wait waits for the end of asynchronous deliveries.
+func (bus *EventBus) Wait() {
+	bus.deliveries.Wait()
+}
+
+// handleError sends the error to the error handler. Nil errors are ignored.
+func (bus *EventBus) handleError(err error) {
+	if err == nil {
+		return
+	}
+	bus.mutex.RLock()
+	handler := bus.errorHandler
+	bus.mutex.RUnlock()
+	if handler != nil {
+		handler(err)
+	}
+}
+
+// subscribe adds a listener to event bus and returns the subscriber.
+func (bus *EventBus) subscribe(listener interface{}) *eventSubscriber {
+	subscriber := &eventSubscriber{listener: listener}
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+	bus.subscribers = append(bus.subscribers, subscriber)
+	return subscriber
+}
+
+// isEventListener returns true if the value implements EventListener interface for an event type:
+// it has an `OnEvent` method with one parameter and without result.
+func isEventListener(value interface{}) bool {
+	if value == nil {
+		return false
+	}
+	method, ok := reflect.TypeOf(value).MethodByName("OnEvent")
+	// method type has the receiver as first parameter
+	return ok && method.Type.NumIn() == 2 && method.Type.NumOut() == 0
+}
+
+// unsubscribe removes a subscriber from event bus.
+func (bus *EventBus) unsubscribe(subscriber *eventSubscriber) {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+	for index, other := range bus.subscribers {
+		if other == subscriber {
+			bus.subscribers = append(bus.subscribers[:index:index], bus.subscribers[index+1:]...)
+			return
+		}
+	}
+}
+
+// Publish delivers synchronously the event to all listeners of E type, in subscription order.
+// Panics of listeners are recovered: function returns the errors of failed listeners.
+func Publish[E any](bus *EventBus, event E) error {
+	failures := make(listenerErrors, 0)
+	for _, listener := range findListeners[E](bus) {
+		if err := deliverEvent(listener, event); err != nil {
+			failures = append(failures, err)
+		}
+	}
+	if len(failures) == 0 {
+		return nil
+	} else if len(failures) == 1 {
+		return failures[0]
+	}
+	return failures
+}
+
+// PublishAsync delivers asynchronously the event to all listeners of E type.
+// Each listener is called in a new goroutine.
+// Panics of listeners are recovered and sent to the event bus error handler (see EventBus.SetErrorHandler).
+func PublishAsync[E any](bus *EventBus, event E) {
+	for _, listener := range findListeners[E](bus) {
+		bus.deliveries.Add(1)
+		go func(listener EventListener[E]) {
+			defer bus.deliveries.Done()
+			bus.handleError(deliverEvent(listener, event))
+		}(listener)
+	}
+}
+
+// findListeners returns the subscribed listeners of E type.
+func findListeners[E any](bus *EventBus) []EventListener[E] {
+	bus.mutex.RLock()
+	defer bus.mutex.RUnlock()
+	listeners := make([]EventListener[E], 0)
+	for _, subscriber := range bus.subscribers {
+		if listener, ok := subscriber.listener.(EventListener[E]); ok {
+			listeners = append(listeners, listener)
+		}
+	}
+	return listeners
+}
+
+// deliverEvent calls the listener with the event.
+// Method returns an error if listener panics.
+func deliverEvent[E any](listener EventListener[E], event E) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = errors.New("event listener %s failed on '%s' event: panic: %v",
+				introsp.TypeName(reflect.TypeOf(listener)), introsp.TypeName(reflect.TypeOf(event)), recovered)
+		}
+	}()
+	listener.OnEvent(event)
+	return nil
+}
+
+// listenerErrors contains the errors of listeners for an event.
+type listenerErrors []error
+
+// Message returns the error description, without listeners errors.
+func (failures listenerErrors) Message() string {
+	return fmt.Sprintf("failed to deliver event, %d listener(s) failed", len(failures))
+}
+
+// Error returns the error description with all listeners errors.
+func (failures listenerErrors) Error() string {
+	message := failures.Message()
+	for _, failure := range failures {
+		message += "\n  - " + strings.ReplaceAll(failure.Error(), "\n", "\n    ")
+	}
+	return message
+}
+
+// Unwrap method returns listeners errors. Multiple errors wrapper method.
+func (failures listenerErrors) Unwrap() []error {
+	return failures
+}
@@ -0,0 +1,131 @@
+package depinject
+
+import (
+	goerr "errors"
+	"github.com/deverdeb/bvmgo-util/errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type userCreatedEventTest struct {
+	name string
+}
+
+type structListenerTest struct {
+	mutex  sync.Mutex
+	events []string
+}
+
+func (test *structListenerTest) OnEvent(event userCreatedEventTest) {
+	test.mutex.Lock()
+	defer test.mutex.Unlock()
+	test.events = append(test.events, event.name)
+}
+
+type structStartListenerTest struct {
+	started int
+}
+
+func (test *structStartListenerTest) OnEvent(event ContextStarted) {
+	test.started++
+}
+
+type structPanicListenerTest struct {
+}
+
+func (test *structPanicListenerTest) OnEvent(event userCreatedEventTest) {
+	panic("listener failure")
+}
+
+func TestContext_Events_ElementsSubscription(t *testing.T) {
+	testContext := CreateContext()
+	listener := &structListenerTest{}
+	_ = testContext.Add(listener)
+	startListener := &structStartListenerTest{}
+	_ = testContext.Add(startListener)
+	// elements are not subscribed before initialization
+	if err := Publish(testContext.Events(), userCreatedEventTest{name: "before start"}); err != nil {
+		t.Errorf("Publish() error = %v, want no error", err)
+	}
+	_ = testContext.Add(&structContextTest2{})
+	_ = testContext.Start()
+	// only listeners are subscribed
+	if subscribers := len(testContext.Events().subscribers); subscribers != 2 {
+		t.Errorf("subscribers = %v, want = %v", subscribers, 2)
+	}
+	if startListener.started != 1 {
+		t.Errorf("startListener.started = %v, want = %v", startListener.started, 1)
+	}
+	if err := Publish(testContext.Events(), userCreatedEventTest{name: "user1"}); err != nil {
+		t.Errorf("Publish() error = %v, want no error", err)
+	}
+	// listener of other events types does not receive event
+	if err := Publish(testContext.Events(), "string event"); err != nil {
+		t.Errorf("Publish() error = %v, want no error", err)
+	}
+	testContext.Stop()
+	// elements are unsubscribed when they are released
+	_ = Publish(testContext.Events(), userCreatedEventTest{name: "after stop"})
+	if len(listener.events) != 1 || listener.events[0] != "user1" {
+		t.Errorf("listener.events = %v, want = %v", listener.events, []string{"user1"})
+	}
+}
+
+func TestEventBus_Subscribe(t *testing.T) {
+	bus := NewEventBus()
+	received := make([]string, 0)
+	unsubscribe := bus.Subscribe(EventListenerFunc[userCreatedEventTest](func(event userCreatedEventTest) {
+		received = append(received, event.name)
+	}))
+	_ = Publish(bus, userCreatedEventTest{name: "user1"})
+	unsubscribe()
+	_ = Publish(bus, userCreatedEventTest{name: "user2"})
+	if len(received) != 1 || received[0] != "user1" {
+		t.Errorf("received = %v, want = %v", received, []string{"user1"})
+	}
+}
+
+func TestPublish_ListenerPanic(t *testing.T) {
+	bus := NewEventBus()
+	listener := &structListenerTest{}
+	bus.Subscribe(&structPanicListenerTest{})
+	bus.Subscribe(listener)
+	bus.Subscribe(&structPanicListenerTest{})
+	err := Publish(bus, userCreatedEventTest{name: "user1"})
+	if err == nil || !strings.Contains(err.Error(), "2 listener(s) failed") ||
+		!strings.Contains(err.Error(), "panic: listener failure") {
+		t.Errorf("Publish() error = %v, want listeners panics errors", err)
+	}
+	var traceableError errors.TraceableError
+	if !goerr.As(err, &traceableError) {
+		t.Errorf("Publish() error = %v, want TraceableError causes", err)
+	}
+	// panics do not stop delivery
+	if len(listener.events) != 1 {
+		t.Errorf("listener.events = %v, want one event", listener.events)
+	}
+}
+
+func TestPublishAsync(t *testing.T) {
+	bus := NewEventBus()
+	var mutex sync.Mutex
+	failures := make([]error, 0)
+	bus.SetErrorHandler(func(err error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		failures = append(failures, err)
+	})
+	listener := &structListenerTest{}
+	bus.Subscribe(listener)
+	bus.Subscribe(&structPanicListenerTest{})
+	PublishAsync(bus, userCreatedEventTest{name: "user1"})
+	PublishAsync(bus, userCreatedEventTest{name: "user2"})
+	bus.Wait()
+	if len(listener.events) != 2 {
+		t.Errorf("listener.events = %v, want 2 events", listener.events)
+	}
+	if len(failures) != 2 {
+		t.Errorf("failures = %v, want 2 errors", failures)
+	}
+}
@@ -0,0 +1,127 @@
+package depinject
+
+import (
+	goerr "errors"
+	"fmt"
+	"github.com/deverdeb/bvmgo-util/errors"
+	"strings"
+)
+
+// errFailedElement is the cause of errors of elements already failed.
+// These errors are not collected: the failure is already collected.
+var errFailedElement = errors.New("element initialization failed")
+
+// InjectionError is a failure of element initialization.
+type InjectionError struct {
+	// element is the failed element description.
+	element string
+	// field is the path of the failed field (example: "BaseService.Logger").
+	// It is empty if failure is not related to a field (`AfterInject()` method error).
+	field string
+	// cause is the failure cause.
+	cause error
+}
+
+// Element returns the failed element description.
+func (err *InjectionError) Element() string {
+	return err.element
+}
+
+// Field returns the path of the failed field.
+// It is empty if failure is not related to a field (`AfterInject()` method error).
+func (err *InjectionError) Field() string {
+	return err.field
+}
+
+// Message returns the failure description, without cause.
+func (err *InjectionError) Message() string {
+	if err.field == "" {
+		return fmt.Sprintf("failed to initialized '%s' element", err.element)
+	}
+	return fmt.Sprintf("failed to inject '%s' field of '%s' element", err.field, err.element)
+}
+
+// Error returns the failure description with cause.
+func (err *InjectionError) Error() string {
+	return err.Message() + "\n    > cause by: " + err.cause.Error()
+}
+
+// Unwrap method returns cause error.
+func (err *InjectionError) Unwrap() error {
+	return err.cause
+}
+
+// StartError is the error returned by Context.Start method when errors are collected (see Context.SetCollectErrors).
+// It contains every independent failure: failures caused by another failed element are not repeated.
+type StartError struct {
+	// failures contains the failures, in detection order.
+	failures []*InjectionError
+}
+
+// Failures returns the failures, in detection order.
+func (err *StartError) Failures() []*InjectionError {
+	return err.failures
+}
+
+// Message returns the error description, without failures.
+func (err *StartError) Message() string {
+	return fmt.Sprintf("failed to start context, %d error(s) found", len(err.failures))
+}
+
+// Error returns the error description with all failures.
+func (err *StartError) Error() string {
+	message := err.Message()
+	for _, failure := range err.failures {
+		message += "\n  - " + strings.ReplaceAll(failure.Error(), "\n", "\n    ")
+	}
+	return message
+}
+
+// Unwrap method returns failures. Multiple errors wrapper method:
+// `errors.Is` and `errors.As` functions of standard errors package check every failure (Go 1.20 and later, see go.mod).
+func (err *StartError) Unwrap() []error {
+	causes := make([]error, 0, len(err.failures))
+	for _, failure := range err.failures {
+		causes = append(causes, failure)
+	}
+	return causes
+}
+
+// startFailures contains the failures found during context start.
+type startFailures struct {
+	// failures contains collected failures.
+	failures []*InjectionError
+	// failedElements contains the elements which failed to initialize.
+	failedElements map[*elementInformation]bool
+}
+
+// newStartFailures creates an empty failures collector.
+func newStartFailures() *startFailures {
+	return &startFailures{
+		failures:       make([]*InjectionError, 0),
+		failedElements: make(map[*elementInformation]bool),
+	}
+}
+
+// add collects an element failure.
+// Failures caused by an already failed element are ignored.
+func (collector *startFailures) add(information *elementInformation, field string, err error) {
+	if goerr.Is(err, errFailedElement) {
+		return
+	}
+	collector.failures = append(collector.failures, &InjectionError{
+		element: information.ToString(),
+		field:   field,
+		cause:   err,
+	})
+}
+
+// markFailed marks the element as failed.
+func (collector *startFailures) markFailed(information *elementInformation) {
+	collector.failedElements[information] = true
+}
+
+// isFailed returns true if element failed to initialize.
+func (collector *startFailures) isFailed(information *elementInformation) bool {
+	return collector.failedElements[information]
+}
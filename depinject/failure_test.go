@@ -0,0 +1,86 @@
+package depinject
+
+import (
+	goerr "errors"
+	"github.com/deverdeb/bvmgo-util/logs"
+	"strings"
+	"testing"
+)
+
+var errAfterInjectTest = goerr.New("after inject test error")
+
+type structFailureTest struct {
+}
+
+func (test *structFailureTest) AfterInject() error {
+	return errAfterInjectTest
+}
+
+func TestContext_Start_CollectErrors(t *testing.T) {
+	testContext := CreateContext()
+	testContext.SetCollectErrors(true)
+	var1 := struct {
+		Field1 interface{} `inject:"unknown1"`
+		Field2 interface{} `inject:"unknown2"`
+	}{}
+	_ = testContext.AddWithName(&var1, "var1")
+	var2 := struct {
+		Field interface{} `inject:"var1"`
+	}{}
+	_ = testContext.AddWithName(&var2, "var2")
+	_ = testContext.AddWithName(&structFailureTest{}, "var3")
+	obj := &structContextTest{}
+	_ = testContext.AddWithName(obj, "var4")
+	err := testContext.Start()
+	if err == nil {
+		t.Errorf("Start() error = %v, want StartError", err)
+		return
+	}
+	var startError *StartError
+	if !goerr.As(err, &startError) {
+		t.Errorf("Start() error = %v, want StartError", err)
+		return
+	}
+	// var2 failure is caused by var1 failure: it is not collected
+	failures := startError.Failures()
+	if len(failures) != 3 {
+		t.Errorf("StartError.Failures() = %v, want 3 failures", failures)
+		return
+	}
+	if failures[0].Field() != "Field1" || failures[1].Field() != "Field2" || failures[2].Field() != "" {
+		t.Errorf("StartError.Failures() fields = %s, %s, %s, want Field1, Field2 and empty field",
+			failures[0].Field(), failures[1].Field(), failures[2].Field())
+	}
+	if !strings.Contains(failures[2].Element(), "var3") {
+		t.Errorf("StartError.Failures()[2].Element() = %s, want contains var3", failures[2].Element())
+	}
+	if !goerr.Is(err, errAfterInjectTest) {
+		t.Errorf("errors.Is(%v, %v) = false, want true", err, errAfterInjectTest)
+	}
+	// initialized elements are released
+	if !obj.release {
+		t.Errorf("obj.release = %v, want = %v", obj.release, true)
+	}
+	formatted := logs.FormatError(err, -1)
+	for _, expected := range []string{"failed to start context, 3 error(s) found",
+		"\n  - failed to inject 'Field1' field of", "missing 'Field2' dependency",
+		"\n      > cause by: after inject test error"} {
+		if !strings.Contains(formatted, expected) {
+			t.Errorf("FormatError() = %v, want contains %v", formatted, expected)
+		}
+	}
+}
+
+func TestContext_Start_CollectErrors_NoError(t *testing.T) {
+	testContext := CreateContext()
+	testContext.SetCollectErrors(true)
+	obj := &structContextTest{}
+	_ = testContext.Add(obj)
+	if err := testContext.Start(); err != nil {
+		t.Errorf("cannot start context, error found: %v", err)
+	}
+	defer testContext.Stop()
+	if !obj.init {
+		t.Errorf("obj.init = %v, want = %v", obj.init, true)
+	}
+}
@@ -0,0 +1,479 @@
+package generator
+
+import (
+	"bytes"
+	"github.com/deverdeb/bvmgo-util/errors"
+	"go/ast"
+	"go/constant"
+	"go/printer"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Tags of injected fields (see depinject.InjectTag and depinject.InjectNestedTag).
+const (
+	injectTag       = "inject"
+	injectNestedTag = "injectNested"
+)
+
+// wiringModel contains the analysed registration function.
+type wiringModel struct {
+	// pkg is the analysed package.
+	pkg *typedPackage
+	// elements contains all registered elements, in registration order.
+	elements []*element
+	// initializationOrder contains all elements, in initialization order.
+	initializationOrder []*element
+	// imports contains the imports required by generated code (import path -> package name).
+	imports map[string]string
+}
+
+// elementStatus is the state of element during initialization order computation.
+type elementStatus int
+
+const (
+	// uninitialized is state of elements not yet ordered
+	uninitialized elementStatus = iota
+	// inInitialization is state of elements during dependencies ordering
+	inInitialization
+	// initialized is state of ordered elements
+	initialized
+)
+
+// element is a registered context element.
+type element struct {
+	// name is the element name in context.
+	name string
+	// eltType is the element type.
+	eltType types.Type
+	// exposedTypes contains the types of element for injections by type.
+	// If it is nil, element is exposed as all types assignable from element type.
+	exposedTypes []types.Type
+	// constructor is the source code of the element expression.
+	constructor ast.Expr
+	// position is the registration position, for error messages.
+	position string
+	// injections contains the element injected fields.
+	injections []*injection
+	// initializable is true if element implements depinject.Initializable interface.
+	initializable bool
+	// releasable is true if element implements depinject.Releasable interface.
+	releasable bool
+	// fieldName is the name of wiring structure field.
+	fieldName string
+	// getterName is the name of wiring structure getter.
+	getterName string
+	// status is the element status during initialization order computation.
+	status elementStatus
+}
+
+// injection is an injected field of an element.
+type injection struct {
+	// path is the field path from the element structure (example: "BaseService.Logger").
+	path string
+	// fieldType is the type of injected field.
+	fieldType types.Type
+	// dependencyName is the dependency name (empty for injection by type).
+	dependencyName string
+	// dependency is the injected element.
+	dependency *element
+	// dereference is true if the dependency pointer must be dereferenced to be assigned.
+	dereference bool
+}
+
+// analyseRegistration extracts elements from registration function and resolves dependencies.
+func analyseRegistration(pkg *typedPackage, registration *ast.FuncDecl) (*wiringModel, error) {
+	wiring := &wiringModel{
+		pkg:      pkg,
+		elements: make([]*element, 0),
+		imports:  make(map[string]string),
+	}
+	var err error
+	topLevelCalls := topLevelCallsOf(registration.Body)
+	ast.Inspect(registration.Body, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok || err != nil {
+			return err == nil
+		}
+		if method, registration := wiring.registrationMethod(call); registration && !topLevelCalls[call] {
+			err = errors.New("unsupported '%s' call (%s), registration calls must be top-level statements of registration function",
+				method, wiring.pkg.position(call))
+		} else if registration {
+			err = wiring.addRegistrationCall(call, method)
+		}
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(wiring.elements) == 0 {
+		return nil, errors.New("no element registered in '%s' function", registration.Name.Name)
+	}
+	for _, elt := range wiring.elements {
+		if err = wiring.resolveInjections(elt); err != nil {
+			return nil, errors.NewWithCause(err, "failed to resolve dependencies of '%s' element (%s)",
+				elt.name, elt.position)
+		}
+	}
+	for _, elt := range wiring.elements {
+		if err = wiring.orderElement(elt); err != nil {
+			return nil, err
+		}
+	}
+	wiring.nameWiringFields()
+	return wiring, nil
+}
+
+// topLevelCallsOf returns the calls of the top-level statements of the function body:
+// `f(...)`, `err := f(...)`, `if err := f(...); ... {}` and `return f(...)` statements.
+// Registration calls are executed once and in order only in these statements.
+func topLevelCallsOf(body *ast.BlockStmt) map[*ast.CallExpr]bool {
+	calls := make(map[*ast.CallExpr]bool)
+	addCall := func(expr ast.Expr) {
+		if call, ok := expr.(*ast.CallExpr); ok {
+			calls[call] = true
+		}
+	}
+	addAssignedCall := func(statement ast.Stmt) {
+		if assign, ok := statement.(*ast.AssignStmt); ok && len(assign.Rhs) == 1 {
+			addCall(assign.Rhs[0])
+		}
+	}
+	for _, statement := range body.List {
+		switch typedStatement := statement.(type) {
+		case *ast.ExprStmt:
+			addCall(typedStatement.X)
+		case *ast.AssignStmt:
+			addAssignedCall(typedStatement)
+		case *ast.IfStmt:
+			addAssignedCall(typedStatement.Init)
+		case *ast.ReturnStmt:
+			if len(typedStatement.Results) == 1 {
+				addCall(typedStatement.Results[0])
+			}
+		}
+	}
+	return calls
+}
+
+// registrationMethod returns the method name and true if call is a `Context.Add(...)`, `Context.AddWithName(...)`,
+// `Context.AddAs(...)` or `Context.AddWithNameAs(...)` call.
+func (wiring *wiringModel) registrationMethod(call *ast.CallExpr) (string, bool) {
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || !wiring.isContextMethod(selector) {
+		return "", false
+	}
+	switch selector.Sel.Name {
+	case "Add", "AddAs", "AddWithName", "AddWithNameAs":
+		return selector.Sel.Name, true
+	default:
+		return "", false
+	}
+}
+
+// addRegistrationCall adds element of `Context.Add(...)`, `Context.AddWithName(...)`, `Context.AddAs(...)`
+// and `Context.AddWithNameAs(...)` calls (see registrationMethod method).
+func (wiring *wiringModel) addRegistrationCall(call *ast.CallExpr, method string) error {
+	var name string
+	var interfaces []ast.Expr
+	switch method {
+	case "Add", "AddAs":
+		if len(call.Args) < 1 || (method == "Add" && len(call.Args) != 1) {
+			return errors.New("unsupported '%s' call (%s), invalid arguments", method, wiring.pkg.position(call))
+		}
+		name = typeName(wiring.pkg.info.TypeOf(call.Args[0]))
+		interfaces = call.Args[1:]
+	case "AddWithName", "AddWithNameAs":
+		if len(call.Args) < 2 || (method == "AddWithName" && len(call.Args) != 2) {
+			return errors.New("unsupported '%s' call (%s), invalid arguments", method, wiring.pkg.position(call))
+		}
+		nameValue := wiring.pkg.info.Types[call.Args[1]].Value
+		if nameValue == nil || nameValue.Kind() != constant.String {
+			return errors.New("unsupported '%s' call (%s), element name must be a constant string",
+				method, wiring.pkg.position(call))
+		}
+		name = constant.StringVal(nameValue)
+		interfaces = call.Args[2:]
+	}
+	if strings.HasSuffix(method, "As") && len(interfaces) == 0 {
+		return errors.New("unsupported '%s' call (%s), require at least one interface type", method, wiring.pkg.position(call))
+	}
+	var exposedTypes []types.Type
+	for _, iface := range interfaces {
+		pointer, ok := wiring.pkg.info.TypeOf(iface).(*types.Pointer)
+		if !ok || !types.IsInterface(pointer.Elem()) {
+			return errors.New("unsupported '%s' call (%s), interface type must be defined by a nil pointer of interface",
+				method, wiring.pkg.position(iface))
+		}
+		exposedTypes = append(exposedTypes, pointer.Elem())
+	}
+	return wiring.addElement(name, call.Args[0], exposedTypes)
+}
+
+// isContextMethod returns true if selector is a method of depinject.Context structure.
+func (wiring *wiringModel) isContextMethod(selector *ast.SelectorExpr) bool {
+	selection, ok := wiring.pkg.info.Selections[selector]
+	if !ok || selection.Kind() != types.MethodVal {
+		return false
+	}
+	named, ok := findNoPointerType(selection.Recv()).(*types.Named)
+	return ok && named.Obj().Name() == "Context" &&
+		named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == depinjectPackagePath
+}
+
+// addElement adds a registered element.
+// If exposedTypes is nil, element is exposed as all types assignable from element type.
+func (wiring *wiringModel) addElement(name string, constructor ast.Expr, exposedTypes []types.Type) error {
+	position := wiring.pkg.position(constructor)
+	eltType := wiring.pkg.info.TypeOf(constructor)
+	if eltType == nil || types.Identical(eltType, types.Typ[types.UntypedNil]) {
+		return errors.New("unsupported '%s' element (%s), context does not support nil element", name, position)
+	}
+	if err := wiring.verifyConstructor(constructor); err != nil {
+		return errors.NewWithCause(err, "unsupported '%s' element (%s)", name, position)
+	}
+	for _, exposedType := range exposedTypes {
+		if !types.Implements(eltType, exposedType.Underlying().(*types.Interface)) {
+			return errors.New("unsupported '%s' element (%s), type %s does not implement %s interface",
+				name, position, typeName(eltType), typeName(exposedType))
+		}
+	}
+	checkedTypes := exposedTypes
+	if checkedTypes == nil {
+		checkedTypes = []types.Type{eltType}
+	}
+	for _, other := range wiring.elements {
+		for _, checkedType := range checkedTypes {
+			if other.name == name && other.matchType(checkedType) {
+				return errors.New("cannot add '%s' element (%s), another element exists with same name and type (%s)",
+					name, position, other.position)
+			}
+		}
+	}
+	wiring.elements = append(wiring.elements, &element{
+		name:          name,
+		eltType:       eltType,
+		exposedTypes:  exposedTypes,
+		constructor:   constructor,
+		position:      position,
+		injections:    make([]*injection, 0),
+		initializable: wiring.implements(eltType, "Initializable"),
+		releasable:    wiring.implements(eltType, "Releasable"),
+	})
+	return nil
+}
+
+// verifyConstructor verifies the element expression can be copied in generated code:
+// identifiers must be package level objects or imported packages.
+// Required imports are added to wiring imports.
+func (wiring *wiringModel) verifyConstructor(constructor ast.Expr) error {
+	var err error
+	ast.Inspect(constructor, func(node ast.Node) bool {
+		ident, ok := node.(*ast.Ident)
+		if !ok || err != nil {
+			return err == nil
+		}
+		object := wiring.pkg.info.Uses[ident]
+		if pkgName, ok := object.(*types.PkgName); ok {
+			wiring.imports[pkgName.Imported().Path()] = pkgName.Name()
+		} else if object != nil && object.Pkg() == wiring.pkg.types && object.Parent() != nil &&
+			object.Parent() != wiring.pkg.types.Scope() {
+			err = errors.New("expression uses '%s' local declaration", ident.Name)
+		}
+		return true
+	})
+	return err
+}
+
+// implements returns true if type implements the depinject interface with the name.
+func (wiring *wiringModel) implements(eltType types.Type, interfaceName string) bool {
+	for _, imported := range wiring.pkg.types.Imports() {
+		if imported.Path() == depinjectPackagePath {
+			object := imported.Scope().Lookup(interfaceName)
+			if object == nil {
+				return false
+			}
+			iface, ok := object.Type().Underlying().(*types.Interface)
+			return ok && types.Implements(eltType, iface)
+		}
+	}
+	return false
+}
+
+// resolveInjections finds the injected fields of element and their dependencies.
+func (wiring *wiringModel) resolveInjections(elt *element) error {
+	structType := findStructType(elt.eltType)
+	if structType == nil {
+		// element is not a structure: no injections
+		return nil
+	}
+	wiring.appendInjections(elt, structType, "", map[types.Type]bool{})
+	if len(elt.injections) > 0 {
+		if _, ok := elt.eltType.(*types.Pointer); !ok {
+			return errors.New("element with injected fields must be a pointer of structure")
+		}
+	}
+	for _, injected := range elt.injections {
+		var err error
+		fieldType := findNoPointerType(injected.fieldType)
+		if injected.dependencyName == "" {
+			injected.dependency, err = wiring.findElement(func(candidate *element) bool {
+				return candidate.matchType(fieldType)
+			})
+			if err != nil {
+				return errors.NewWithCause(err, "failed to find '%s' dependency (by type: %s)",
+					injected.path, typeName(fieldType))
+			} else if injected.dependency == nil {
+				return errors.New("missing '%s' dependency (by type: %s)", injected.path, typeName(fieldType))
+			}
+		} else {
+			injected.dependency, err = wiring.findElement(func(candidate *element) bool {
+				return candidate.name == injected.dependencyName
+			})
+			if err != nil {
+				return errors.NewWithCause(err, "failed to find '%s' dependency (by name: %s)",
+					injected.path, injected.dependencyName)
+			} else if injected.dependency == nil {
+				return errors.New("missing '%s' dependency (by name: %s)", injected.path, injected.dependencyName)
+			}
+		}
+		dependencyType := injected.dependency.eltType
+		if pointer, ok := dependencyType.(*types.Pointer); ok && !isPointer(injected.fieldType) &&
+			!types.AssignableTo(dependencyType, injected.fieldType) {
+			dependencyType = pointer.Elem()
+			injected.dereference = true
+		}
+		if !types.AssignableTo(dependencyType, injected.fieldType) {
+			return errors.New("'%s' dependency type '%s' can not assign to field type '%s'",
+				injected.path, typeName(injected.dependency.eltType), typeName(injected.fieldType))
+		}
+	}
+	return nil
+}
+
+// appendInjections appends injected fields of the structure to element injections.
+// Fields of embedded structures and nested structures are searched recursively.
+func (wiring *wiringModel) appendInjections(elt *element, structType *types.Struct, parentPath string,
+	visitedTypes map[types.Type]bool) {
+	if structType == nil || visitedTypes[structType] {
+		return
+	}
+	visitedTypes[structType] = true
+	defer delete(visitedTypes, structType)
+	for fieldIndex := 0; fieldIndex < structType.NumFields(); fieldIndex++ {
+		field := structType.Field(fieldIndex)
+		tag := reflect.StructTag(structType.Tag(fieldIndex))
+		path := field.Name()
+		if parentPath != "" {
+			path = parentPath + "." + field.Name()
+		}
+		if tagValue, ok := tag.Lookup(injectTag); ok {
+			elt.injections = append(elt.injections, &injection{
+				path:           path,
+				fieldType:      field.Type(),
+				dependencyName: strings.TrimSpace(tagValue),
+			})
+		} else if _, ok = tag.Lookup(injectNestedTag); ok || field.Anonymous() {
+			wiring.appendInjections(elt, findStructType(field.Type()), path, visitedTypes)
+		}
+	}
+}
+
+// findElement returns the element matching the filter.
+// Method returns error if more than one element is found, and nil if no element is found.
+func (wiring *wiringModel) findElement(filter func(candidate *element) bool) (*element, error) {
+	finds := make([]*element, 0)
+	for _, candidate := range wiring.elements {
+		if filter(candidate) {
+			finds = append(finds, candidate)
+		}
+	}
+	if len(finds) == 1 {
+		return finds[0], nil
+	} else if len(finds) == 0 {
+		return nil, nil
+	}
+	eltsInfo := ""
+	for _, candidate := range finds {
+		if len(eltsInfo) != 0 {
+			eltsInfo = eltsInfo + ", "
+		}
+		eltsInfo += "'" + candidate.name + "' (" + candidate.position + ")"
+	}
+	return nil, errors.New("too many elements: %s", eltsInfo)
+}
+
+// orderElement adds element (after its dependencies) to initialization order.
+func (wiring *wiringModel) orderElement(elt *element) error {
+	if elt.status == inInitialization {
+		return errors.New("failed to order '%s' element (%s), potential dependency loop", elt.name, elt.position)
+	}
+	if elt.status == initialized {
+		return nil
+	}
+	elt.status = inInitialization
+	for _, injected := range elt.injections {
+		if err := wiring.orderElement(injected.dependency); err != nil {
+			return errors.NewWithCause(err, "failed to order '%s' dependency of '%s' element", injected.path, elt.name)
+		}
+	}
+	elt.status = initialized
+	wiring.initializationOrder = append(wiring.initializationOrder, elt)
+	return nil
+}
+
+// nameWiringFields defines unique wiring structure field and getter names of elements.
+func (wiring *wiringModel) nameWiringFields() {
+	usedNames := map[string]bool{"Start": true, "Stop": true, "initialized": true}
+	for _, elt := range wiring.elements {
+		base := identifier(elt.name)
+		name := base
+		for index := 2; usedNames[name] || usedNames[lowerFirst(name)]; index++ {
+			name = base + strconv.Itoa(index)
+		}
+		usedNames[name] = true
+		elt.getterName = name
+		elt.fieldName = lowerFirst(name)
+	}
+}
+
+// source returns the source code of expression.
+func (wiring *wiringModel) source(expression ast.Expr) string {
+	var buffer bytes.Buffer
+	_ = printer.Fprint(&buffer, wiring.pkg.fileSet, expression)
+	return buffer.String()
+}
+
+// typeString returns the type declaration in generated code, and adds required imports.
+func (wiring *wiringModel) typeString(eltType types.Type) string {
+	return types.TypeString(eltType, func(pkg *types.Package) string {
+		if pkg == wiring.pkg.types {
+			return ""
+		}
+		if name, ok := wiring.imports[pkg.Path()]; ok {
+			return name
+		}
+		wiring.imports[pkg.Path()] = pkg.Name()
+		return pkg.Name()
+	})
+}
+
+// matchType returns true if element can be injected by type in a field of fieldType.
+// Rules are the same as depinject.Context rules.
+func (elt *element) matchType(fieldType types.Type) bool {
+	if elt.exposedTypes != nil {
+		for _, exposedType := range elt.exposedTypes {
+			if types.Identical(exposedType, fieldType) {
+				return true
+			}
+		}
+		return false
+	}
+	if types.Identical(elt.eltType, fieldType) || types.AssignableTo(elt.eltType, fieldType) {
+		return true
+	}
+	pointer, ok := elt.eltType.(*types.Pointer)
+	return ok && types.AssignableTo(pointer.Elem(), fieldType)
+}
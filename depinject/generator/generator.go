@@ -0,0 +1,137 @@
+// Package generator generates reflection-free wiring code for depinject contexts.
+//
+// The generator reads the registration function of a package (calls to `Context.Add(...)`,
+// `Context.AddWithName(...)`, `Context.AddAs(...)` and `Context.AddWithNameAs(...)`) and the `inject` tags
+// of registered elements. Registration calls must be top-level statements of the registration function
+// (`context.Add(...)`, `err := context.Add(...)`, `if err := context.Add(...); err != nil {...}`
+// or `return context.Add(...)`): conditional registrations, loops and closures are rejected.
+// It writes a wiring structure which builds elements, injects dependencies, calls `AfterInject()`
+// and `Release()` methods with the same order and semantics as `depinject.Context`.
+package generator
+
+import (
+	"bytes"
+	"github.com/deverdeb/bvmgo-util/errors"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// depinjectPackagePath is the import path of depinject package.
+const depinjectPackagePath = "github.com/deverdeb/bvmgo-util/depinject"
+
+// Options contains the generator configuration.
+type Options struct {
+	// Function is the name of the registration function (package level function).
+	Function string
+	// TypeName is the name of the generated wiring structure.
+	TypeName string
+	// Output is the name of the generated file. This file is ignored when package is read.
+	Output string
+	// Command is the generator command written in generated file header.
+	Command string
+}
+
+// DefaultOptions returns the default generator configuration.
+func DefaultOptions() Options {
+	return Options{
+		Function: "Register",
+		TypeName: "Wiring",
+		Output:   "depinject_wiring.go",
+		Command:  "depinjectgen",
+	}
+}
+
+// Generate reads the package of the directory and returns the wiring source code.
+func Generate(dir string, options Options) ([]byte, error) {
+	pkg, err := loadPackage(dir, options)
+	if err != nil {
+		return nil, err
+	}
+	registration, err := findRegistrationFunction(pkg, options.Function)
+	if err != nil {
+		return nil, err
+	}
+	wiring, err := analyseRegistration(pkg, registration)
+	if err != nil {
+		return nil, errors.NewWithCause(err, "failed to analyse '%s' registration function", options.Function)
+	}
+	var buffer bytes.Buffer
+	writeWiring(&buffer, wiring, options)
+	source, err := format.Source(buffer.Bytes())
+	if err != nil {
+		return nil, errors.NewWithCause(err, "failed to format generated code")
+	}
+	return source, nil
+}
+
+// typedPackage is a parsed and type checked package.
+type typedPackage struct {
+	fileSet *token.FileSet
+	files   []*ast.File
+	types   *types.Package
+	info    *types.Info
+}
+
+// loadPackage parses and type checks the package of the directory.
+// Test files and the generated file are ignored.
+func loadPackage(dir string, options Options) (*typedPackage, error) {
+	fileSet := token.NewFileSet()
+	filter := func(info fs.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go") && info.Name() != filepath.Base(options.Output)
+	}
+	packages, err := parser.ParseDir(fileSet, dir, filter, parser.ParseComments)
+	if err != nil {
+		return nil, errors.NewWithCause(err, "failed to parse '%s' package directory", dir)
+	}
+	if len(packages) != 1 {
+		return nil, errors.New("require one package in '%s' directory, found %d packages", dir, len(packages))
+	}
+	pkg := &typedPackage{
+		fileSet: fileSet,
+		info: &types.Info{
+			Types:      make(map[ast.Expr]types.TypeAndValue),
+			Uses:       make(map[*ast.Ident]types.Object),
+			Selections: make(map[*ast.SelectorExpr]*types.Selection),
+		},
+	}
+	var packageName string
+	for name, astPackage := range packages {
+		packageName = name
+		for _, file := range astPackage.Files {
+			pkg.files = append(pkg.files, file)
+		}
+	}
+	config := types.Config{Importer: importer.ForCompiler(fileSet, "source", nil)}
+	pkg.types, err = config.Check(packageName, fileSet, pkg.files, pkg.info)
+	if err != nil {
+		return nil, errors.NewWithCause(err, "failed to check '%s' package types", packageName)
+	}
+	return pkg, nil
+}
+
+// findRegistrationFunction returns the package level function with the name.
+func findRegistrationFunction(pkg *typedPackage, name string) (*ast.FuncDecl, error) {
+	for _, file := range pkg.files {
+		for _, declaration := range file.Decls {
+			function, ok := declaration.(*ast.FuncDecl)
+			if ok && function.Recv == nil && function.Name.Name == name && function.Body != nil {
+				return function, nil
+			}
+		}
+	}
+	return nil, errors.New("cannot find '%s' registration function in '%s' package", name, pkg.types.Name())
+}
+
+// position returns the source position of node, for error messages.
+func (pkg *typedPackage) position(node ast.Node) string {
+	position := pkg.fileSet.Position(node.Pos())
+	return filepath.Base(position.Filename) + ":" + strconv.Itoa(position.Line)
+}
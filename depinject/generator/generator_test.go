@@ -0,0 +1,133 @@
+package generator
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	options := DefaultOptions()
+	source, err := Generate(filepath.Join("testdata", "wiring"), options)
+	if err != nil {
+		t.Errorf("Generate() error = %v, want no error", err)
+		return
+	}
+	expected, err := os.ReadFile(filepath.Join("testdata", "wiring", options.Output))
+	if err != nil {
+		t.Errorf("cannot read expected generated file: %v", err)
+		return
+	}
+	if string(source) != string(expected) {
+		t.Errorf("Generate() = \n%s\nwant = \n%s", source, expected)
+	}
+}
+
+func TestGenerate_ExposedTypes(t *testing.T) {
+	source, err := Generate(filepath.Join("testdata", "exposed"), DefaultOptions())
+	if err != nil {
+		t.Errorf("Generate() error = %v, want no error", err)
+		return
+	}
+	if want := "wiring.elements.service.Store = wiring.elements.memoryStore"; !strings.Contains(string(source), want) {
+		t.Errorf("Generate() = \n%s\nwant contains '%s'", source, want)
+	}
+}
+
+func TestGenerate_MissingDependency(t *testing.T) {
+	_, err := Generate(filepath.Join("testdata", "missing"), DefaultOptions())
+	if err == nil || !strings.Contains(err.Error(), "missing 'Name' dependency (by name: serviceName)") {
+		t.Errorf("Generate() error = %v, want contains \"missing 'Name' dependency (by name: serviceName)\"", err)
+	}
+}
+
+func TestGenerate_MissingFunction(t *testing.T) {
+	options := DefaultOptions()
+	options.Function = "Unknown"
+	_, err := Generate(filepath.Join("testdata", "wiring"), options)
+	if err == nil || !strings.Contains(err.Error(), "cannot find 'Unknown' registration function") {
+		t.Errorf("Generate() error = %v, want contains \"cannot find 'Unknown' registration function\"", err)
+	}
+}
+
+func Test_identifier(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "*MyService", want: "MyService"},
+		{name: "my-config", want: "MyConfig"},
+		{name: "42", want: "Element42"},
+		{name: "map[string]int", want: "MapStringInt"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := identifier(tt.name); got != tt.want {
+				t.Errorf("identifier() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerate_NestedRegistration(t *testing.T) {
+	tests := []struct {
+		function string
+		want     string
+	}{
+		{function: "RegisterInCondition", want: "unsupported 'AddWithName' call (nested.go:14), registration calls must be top-level statements"},
+		{function: "RegisterInLoop", want: "unsupported 'AddWithName' call (nested.go:22), registration calls must be top-level statements"},
+		{function: "RegisterInClosure", want: "unsupported 'AddWithName' call (nested.go:29), registration calls must be top-level statements"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.function, func(t *testing.T) {
+			options := DefaultOptions()
+			options.Function = tt.function
+			_, err := Generate(filepath.Join("testdata", "nested"), options)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Generate() error = %v, want contains \"%s\"", err, tt.want)
+			}
+		})
+	}
+}
+
+// TestGenerate_CompileAndRun builds the wiring package with the generated code in a temporary module,
+// and runs its tests (testdata/wiring/wiring_test.go): the wiring must behave as depinject.Context.
+func TestGenerate_CompileAndRun(t *testing.T) {
+	if testing.Short() {
+		t.Skip("compilation of generated code skipped in short mode")
+	}
+	moduleRoot, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatalf("cannot find module root directory: %v", err)
+	}
+	options := DefaultOptions()
+	source, err := Generate(filepath.Join("testdata", "wiring"), options)
+	if err != nil {
+		t.Fatalf("Generate() error = %v, want no error", err)
+	}
+	dir := t.TempDir()
+	files := map[string][]byte{
+		options.Output: source,
+		"go.mod": []byte("module example.com/wiring\n\ngo 1.20\n\nrequire github.com/deverdeb/bvmgo-util v0.0.0\n\n" +
+			"replace github.com/deverdeb/bvmgo-util => " + filepath.ToSlash(moduleRoot) + "\n"),
+	}
+	for _, name := range []string{"wiring.go", "wiring_test.go"} {
+		if files[name], err = os.ReadFile(filepath.Join("testdata", "wiring", name)); err != nil {
+			t.Fatalf("cannot read '%s' file: %v", name, err)
+		}
+	}
+	for name, content := range files {
+		if err = os.WriteFile(filepath.Join(dir, name), content, 0644); err != nil {
+			t.Fatalf("cannot write '%s' file: %v", name, err)
+		}
+	}
+	command := exec.Command(filepath.Join(runtime.GOROOT(), "bin", "go"), "test", "-count=1", ".")
+	command.Dir = dir
+	command.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOWORK=off", "GOPROXY=off", "GOTOOLCHAIN=local")
+	if output, err := command.CombinedOutput(); err != nil {
+		t.Errorf("generated code tests failed: %v\n%s", err, output)
+	}
+}
@@ -0,0 +1,40 @@
+package exposed
+
+import "github.com/deverdeb/bvmgo-util/depinject"
+
+type Store interface {
+	Load(key string) string
+}
+
+type Loader interface {
+	Load(key string) string
+}
+
+type MemoryStore struct {
+}
+
+func (store *MemoryStore) Load(key string) string {
+	return key
+}
+
+type FileStore struct {
+}
+
+func (store *FileStore) Load(key string) string {
+	return key
+}
+
+type Service struct {
+	Store Store `inject:""`
+}
+
+// Register exposes FileStore only as a Loader: MemoryStore is the only Store.
+func Register(context *depinject.Context) error {
+	if err := context.Add(&Service{}); err != nil {
+		return err
+	}
+	if err := context.AddAs(&MemoryStore{}, (*Store)(nil)); err != nil {
+		return err
+	}
+	return context.AddWithNameAs(&FileStore{}, "fileStore", (*Loader)(nil))
+}
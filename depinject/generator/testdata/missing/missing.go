@@ -0,0 +1,11 @@
+package missing
+
+import "github.com/deverdeb/bvmgo-util/depinject"
+
+type Service struct {
+	Name string `inject:"serviceName"`
+}
+
+func Register(context *depinject.Context) {
+	_ = context.Add(&Service{})
+}
@@ -0,0 +1,32 @@
+package nested
+
+import "github.com/deverdeb/bvmgo-util/depinject"
+
+type Service struct {
+	Name string `inject:"serviceName"`
+}
+
+func RegisterInCondition(context *depinject.Context, enabled bool) error {
+	if err := context.Add(&Service{}); err != nil {
+		return err
+	}
+	if enabled {
+		return context.AddWithName("my-service", "serviceName")
+	}
+	return nil
+}
+
+func RegisterInLoop(context *depinject.Context) {
+	_ = context.Add(&Service{})
+	for _, name := range []string{"first", "second"} {
+		_ = context.AddWithName(name, "serviceName")
+	}
+}
+
+func RegisterInClosure(context *depinject.Context) {
+	_ = context.Add(&Service{})
+	register := func() {
+		_ = context.AddWithName("my-service", "serviceName")
+	}
+	register()
+}
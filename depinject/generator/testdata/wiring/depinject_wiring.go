@@ -0,0 +1,149 @@
+// Code generated by depinjectgen. DO NOT EDIT.
+
+package wiring
+
+import (
+	depinjecterrors "github.com/deverdeb/bvmgo-util/errors"
+	"time"
+)
+
+// Wiring contains the context elements registered by Register function, wired without reflection.
+type Wiring struct {
+	// initialized is the number of initialized elements, in initialization order.
+	initialized int
+	// elements contains the context elements.
+	elements struct {
+		service     *Service
+		config      *Config
+		serviceName string
+		memoryStore *MemoryStore
+	}
+}
+
+// NewWiring builds the context elements.
+// Dependencies are injected by Start method.
+func NewWiring() *Wiring {
+	wiring := &Wiring{}
+	wiring.elements.service = &Service{}
+	wiring.elements.config = &Config{Timeout: 10 * time.Second}
+	wiring.elements.serviceName = "my-service"
+	wiring.elements.memoryStore = &MemoryStore{}
+	return wiring
+}
+
+// Service returns the '*Service' element.
+func (wiring *Wiring) Service() *Service {
+	return wiring.elements.service
+}
+
+// Config returns the 'config' element.
+func (wiring *Wiring) Config() *Config {
+	return wiring.elements.config
+}
+
+// ServiceName returns the 'serviceName' element.
+func (wiring *Wiring) ServiceName() string {
+	return wiring.elements.serviceName
+}
+
+// MemoryStore returns the '*MemoryStore' element.
+func (wiring *Wiring) MemoryStore() *MemoryStore {
+	return wiring.elements.memoryStore
+}
+
+// Start injects dependencies and calls `AfterInject()` methods of elements, in initialization order.
+// If an element fails, initialized elements are released.
+func (wiring *Wiring) Start() error {
+	initializers := []func() error{
+		wiring.initializeConfig,
+		wiring.initializeMemoryStore,
+		wiring.initializeServiceName,
+		wiring.initializeService,
+	}
+	for index := wiring.initialized; index < len(initializers); index++ {
+		wiring.initialized++
+		if err := initializers[index](); err != nil {
+			// failed element is already released
+			wiring.initialized = index
+			wiring.Stop()
+			return err
+		}
+	}
+	return nil
+}
+
+// Stop calls `Release()` methods of initialized elements and removes dependencies.
+func (wiring *Wiring) Stop() {
+	releasers := []func(){
+		wiring.releaseConfig,
+		wiring.releaseMemoryStore,
+		wiring.releaseServiceName,
+		wiring.releaseService,
+	}
+	for index := 0; index < wiring.initialized; index++ {
+		releasers[index]()
+	}
+	wiring.initialized = 0
+}
+
+// initializeConfig injects dependencies of 'config' element.
+func (wiring *Wiring) initializeConfig() error {
+	return nil
+}
+
+// releaseConfig removes dependencies of 'config' element.
+func (wiring *Wiring) releaseConfig() {
+}
+
+// initializeMemoryStore injects dependencies of '*MemoryStore' element and calls `AfterInject()` method.
+func (wiring *Wiring) initializeMemoryStore() error {
+	wiring.elements.memoryStore.Config = wiring.elements.config
+	if err := wiring.elements.memoryStore.AfterInject(); err != nil {
+		wiring.releaseMemoryStore()
+		return depinjecterrors.NewWithCause(err, "failed to start context, "+
+			"error during '%s' element initialization", "*MemoryStore")
+	}
+	return nil
+}
+
+// releaseMemoryStore calls `Release()` method and removes dependencies of '*MemoryStore' element.
+func (wiring *Wiring) releaseMemoryStore() {
+	wiring.elements.memoryStore.Release()
+	wiring.elements.memoryStore.Config = nil
+}
+
+// initializeServiceName injects dependencies of 'serviceName' element.
+func (wiring *Wiring) initializeServiceName() error {
+	return nil
+}
+
+// releaseServiceName removes dependencies of 'serviceName' element.
+func (wiring *Wiring) releaseServiceName() {
+}
+
+// initializeService injects dependencies of '*Service' element and calls `AfterInject()` method.
+func (wiring *Wiring) initializeService() error {
+	wiring.elements.service.BaseService.Store = wiring.elements.memoryStore
+	wiring.elements.service.Name = wiring.elements.serviceName
+	wiring.elements.service.Options.Config = *wiring.elements.config
+	if err := wiring.elements.service.AfterInject(); err != nil {
+		wiring.releaseService()
+		return depinjecterrors.NewWithCause(err, "failed to start context, "+
+			"error during '%s' element initialization", "*Service")
+	}
+	return nil
+}
+
+// releaseService calls `Release()` method and removes dependencies of '*Service' element.
+func (wiring *Wiring) releaseService() {
+	wiring.elements.service.Release()
+	wiring.elements.service.BaseService.Store = nil
+	resetWiringValue(&wiring.elements.service.Name)
+	resetWiringValue(&wiring.elements.service.Options.Config)
+}
+
+// resetWiringValue assigns the zero value to the variable.
+func resetWiringValue[T any](variable *T) {
+	var zero T
+	*variable = zero
+}
@@ -0,0 +1,76 @@
+package wiring
+
+//go:generate go run github.com/deverdeb/bvmgo-util/depinject/cmd/depinjectgen
+
+import (
+	"github.com/deverdeb/bvmgo-util/depinject"
+	"time"
+)
+
+// events records the `AfterInject()` and `Release()` calls, to compare the wiring with depinject.Context.
+var events []string
+
+// serviceFailure is the error returned by `Service.AfterInject()` method (nil if service starts).
+var serviceFailure error
+
+type Store interface {
+	Load(key string) string
+}
+
+type Config struct {
+	Timeout time.Duration
+}
+
+type MemoryStore struct {
+	Config *Config `inject:""`
+	values map[string]string
+}
+
+func (store *MemoryStore) Load(key string) string {
+	return store.values[key]
+}
+
+func (store *MemoryStore) AfterInject() error {
+	events = append(events, "AfterInject *MemoryStore")
+	store.values = make(map[string]string)
+	return nil
+}
+
+func (store *MemoryStore) Release() {
+	events = append(events, "Release *MemoryStore")
+	store.values = nil
+}
+
+type BaseService struct {
+	Store Store `inject:""`
+}
+
+type Service struct {
+	BaseService
+	Name    string `inject:"serviceName"`
+	Options struct {
+		Config Config `inject:"config"`
+	} `injectNested:""`
+}
+
+func (service *Service) AfterInject() error {
+	events = append(events, "AfterInject *Service")
+	return serviceFailure
+}
+
+func (service *Service) Release() {
+	events = append(events, "Release *Service")
+}
+
+func Register(context *depinject.Context) error {
+	if err := context.Add(&Service{}); err != nil {
+		return err
+	}
+	if err := context.AddWithName(&Config{Timeout: 10 * time.Second}, "config"); err != nil {
+		return err
+	}
+	if err := context.AddWithName("my-service", "serviceName"); err != nil {
+		return err
+	}
+	return context.Add(&MemoryStore{})
+}
@@ -0,0 +1,72 @@
+package wiring
+
+import (
+	"errors"
+	"github.com/deverdeb/bvmgo-util/depinject"
+	"reflect"
+	"testing"
+)
+
+// startContext registers the elements in a depinject.Context and starts it.
+// It returns the context and the recorded events.
+func startContext(t *testing.T) (*depinject.Context, []string, error) {
+	events = nil
+	context := depinject.CreateContext()
+	if err := Register(&context); err != nil {
+		t.Fatalf("Register() error = %v, want no error", err)
+	}
+	err := context.Start()
+	return &context, events, err
+}
+
+func TestWiring_Start(t *testing.T) {
+	context, wantStartEvents, err := startContext(t)
+	if err != nil {
+		t.Fatalf("Context.Start() error = %v, want no error", err)
+	}
+	context.Stop()
+	wantStopEvents := events[len(wantStartEvents):]
+
+	events = nil
+	wiring := NewWiring()
+	if err = wiring.Start(); err != nil {
+		t.Fatalf("Start() error = %v, want no error", err)
+	}
+	if !reflect.DeepEqual(events, wantStartEvents) {
+		t.Errorf("Start() events = %v, want %v", events, wantStartEvents)
+	}
+	service := wiring.Service()
+	if service.Store != wiring.MemoryStore() || service.Name != "my-service" ||
+		service.Options.Config != *wiring.Config() || wiring.MemoryStore().Config != wiring.Config() {
+		t.Errorf("Start() service = %+v, want injected dependencies", service)
+	}
+	events = nil
+	wiring.Stop()
+	if !reflect.DeepEqual(events, wantStopEvents) {
+		t.Errorf("Stop() events = %v, want %v", events, wantStopEvents)
+	}
+	if service.Store != nil || service.Name != "" || wiring.MemoryStore().Config != nil {
+		t.Errorf("Stop() service = %+v, want removed dependencies", service)
+	}
+}
+
+func TestWiring_StartFailure(t *testing.T) {
+	serviceFailure = errors.New("service failure")
+	defer func() { serviceFailure = nil }()
+	_, wantEvents, err := startContext(t)
+	if !errors.Is(err, serviceFailure) {
+		t.Fatalf("Context.Start() error = %v, want service failure", err)
+	}
+
+	events = nil
+	wiring := NewWiring()
+	if err = wiring.Start(); !errors.Is(err, serviceFailure) {
+		t.Errorf("Start() error = %v, want service failure", err)
+	}
+	if !reflect.DeepEqual(events, wantEvents) {
+		t.Errorf("Start() events = %v, want %v (failed element and initialized elements released)", events, wantEvents)
+	}
+	if wiring.Service().Store != nil || wiring.MemoryStore().Config != nil {
+		t.Errorf("Start() service = %+v, want removed dependencies", wiring.Service())
+	}
+}
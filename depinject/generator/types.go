@@ -0,0 +1,99 @@
+package generator
+
+import (
+	"go/types"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// typeName returns the type name, with the same format as introsp.TypeName function.
+func typeName(eltType types.Type) string {
+	switch typed := eltType.(type) {
+	case nil:
+		return "<nil>"
+	case *types.Pointer:
+		return "*" + typeName(typed.Elem())
+	case *types.Slice:
+		return "[]" + typeName(typed.Elem())
+	case *types.Array:
+		return "[" + strconv.FormatInt(typed.Len(), 10) + "]" + typeName(typed.Elem())
+	case *types.Map:
+		return "map[" + typeName(typed.Key()) + "]" + typeName(typed.Elem())
+	case *types.Chan:
+		return "chan " + typeName(typed.Elem())
+	case *types.Named:
+		return typed.Obj().Name()
+	case *types.Basic:
+		return typed.Name()
+	default:
+		return ""
+	}
+}
+
+// findStructType extracts the structure type.
+// If type is a pointer, return the pointed structure type.
+// Return nil if type is not a structure.
+func findStructType(eltType types.Type) *types.Struct {
+	if pointer, ok := eltType.(*types.Pointer); ok {
+		return findStructType(pointer.Elem())
+	}
+	if eltType == nil {
+		return nil
+	}
+	structType, _ := eltType.Underlying().(*types.Struct)
+	return structType
+}
+
+// findNoPointerType extracts the type. If type is a pointer, return the pointed type.
+func findNoPointerType(eltType types.Type) types.Type {
+	if pointer, ok := eltType.(*types.Pointer); ok {
+		return findNoPointerType(pointer.Elem())
+	}
+	return eltType
+}
+
+// isPointer returns true if type is a pointer.
+func isPointer(eltType types.Type) bool {
+	_, ok := eltType.(*types.Pointer)
+	return ok
+}
+
+// isNillable returns true if nil can be assigned to type.
+func isNillable(eltType types.Type) bool {
+	switch eltType.Underlying().(type) {
+	case *types.Pointer, *types.Interface, *types.Slice, *types.Map, *types.Chan, *types.Signature:
+		return true
+	default:
+		return false
+	}
+}
+
+// identifier converts an element name to an exported Go identifier.
+// Example: "*MyService" -> "MyService", "my-config" -> "MyConfig".
+func identifier(name string) string {
+	var builder strings.Builder
+	upper := true
+	for _, character := range name {
+		if unicode.IsLetter(character) || unicode.IsDigit(character) {
+			if upper {
+				character = unicode.ToUpper(character)
+			}
+			builder.WriteRune(character)
+			upper = false
+		} else {
+			upper = true
+		}
+	}
+	if builder.Len() == 0 || !unicode.IsUpper([]rune(builder.String())[0]) {
+		return "Element" + builder.String()
+	}
+	return builder.String()
+}
+
+// lowerFirst returns the identifier with a lower case first letter.
+func lowerFirst(name string) string {
+	runes := []rune(name)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
@@ -0,0 +1,206 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+)
+
+// errorsPackagePath is the import path of errors package, used by generated code.
+const errorsPackagePath = "github.com/deverdeb/bvmgo-util/errors"
+
+// errorsPackageName is the local name of errors package in generated code.
+const errorsPackageName = "depinjecterrors"
+
+// writeWiring writes the generated source code (not formatted).
+func writeWiring(output io.Writer, wiring *wiringModel, options Options) {
+	// Body is written first: types declarations add required imports.
+	var body bytes.Buffer
+	writeWiringStructure(&body, wiring, options)
+	writeWiringStart(&body, wiring, options)
+	writeWiringStop(&body, wiring, options)
+	resetRequired := false
+	for _, elt := range wiring.initializationOrder {
+		writeElementInitialization(&body, elt, options)
+		resetRequired = writeElementRelease(&body, elt, options) || resetRequired
+	}
+	if resetRequired {
+		writeResetValue(&body, options)
+	}
+
+	_, _ = fmt.Fprintf(output, "// Code generated by %s. DO NOT EDIT.\n\n", options.Command)
+	_, _ = fmt.Fprintf(output, "package %s\n\n", wiring.pkg.types.Name())
+	writeImports(output, wiring)
+	_, _ = output.Write(body.Bytes())
+}
+
+// writeImports writes the imports of generated code.
+func writeImports(output io.Writer, wiring *wiringModel) {
+	for _, elt := range wiring.initializationOrder {
+		if elt.initializable {
+			wiring.imports[errorsPackagePath] = errorsPackageName
+		}
+	}
+	if len(wiring.imports) == 0 {
+		return
+	}
+	importPaths := make([]string, 0, len(wiring.imports))
+	for importPath := range wiring.imports {
+		importPaths = append(importPaths, importPath)
+	}
+	sort.Strings(importPaths)
+	_, _ = fmt.Fprintln(output, "import (")
+	for _, importPath := range importPaths {
+		if name := wiring.imports[importPath]; name != path.Base(importPath) {
+			_, _ = fmt.Fprintf(output, "\t%s %s\n", name, strconv.Quote(importPath))
+		} else {
+			_, _ = fmt.Fprintf(output, "\t%s\n", strconv.Quote(importPath))
+		}
+	}
+	_, _ = fmt.Fprintln(output, ")")
+	_, _ = fmt.Fprintln(output)
+}
+
+// writeWiringStructure writes the wiring structure, its constructor and elements getters.
+func writeWiringStructure(output io.Writer, wiring *wiringModel, options Options) {
+	typeName := options.TypeName
+	_, _ = fmt.Fprintf(output, "// %s contains the context elements registered by %s function, wired without reflection.\n",
+		typeName, options.Function)
+	_, _ = fmt.Fprintf(output, "type %s struct {\n", typeName)
+	_, _ = fmt.Fprintln(output, "\t// initialized is the number of initialized elements, in initialization order.")
+	_, _ = fmt.Fprintln(output, "\tinitialized int")
+	_, _ = fmt.Fprintln(output, "\t// elements contains the context elements.")
+	_, _ = fmt.Fprintln(output, "\telements struct {")
+	for _, elt := range wiring.elements {
+		_, _ = fmt.Fprintf(output, "\t\t%s %s\n", elt.fieldName, wiring.typeString(elt.eltType))
+	}
+	_, _ = fmt.Fprintln(output, "\t}")
+	_, _ = fmt.Fprintln(output, "}")
+	_, _ = fmt.Fprintln(output)
+
+	_, _ = fmt.Fprintf(output, "// New%s builds the context elements.\n", typeName)
+	_, _ = fmt.Fprintln(output, "// Dependencies are injected by Start method.")
+	_, _ = fmt.Fprintf(output, "func New%s() *%s {\n", typeName, typeName)
+	_, _ = fmt.Fprintf(output, "\twiring := &%s{}\n", typeName)
+	for _, elt := range wiring.elements {
+		_, _ = fmt.Fprintf(output, "\twiring.elements.%s = %s\n", elt.fieldName, wiring.source(elt.constructor))
+	}
+	_, _ = fmt.Fprintln(output, "\treturn wiring")
+	_, _ = fmt.Fprintln(output, "}")
+	_, _ = fmt.Fprintln(output)
+
+	for _, elt := range wiring.elements {
+		_, _ = fmt.Fprintf(output, "// %s returns the '%s' element.\n", elt.getterName, elt.name)
+		_, _ = fmt.Fprintf(output, "func (wiring *%s) %s() %s {\n", typeName, elt.getterName, wiring.typeString(elt.eltType))
+		_, _ = fmt.Fprintf(output, "\treturn wiring.elements.%s\n", elt.fieldName)
+		_, _ = fmt.Fprintln(output, "}")
+		_, _ = fmt.Fprintln(output)
+	}
+}
+
+// writeWiringStart writes the Start method.
+func writeWiringStart(output io.Writer, wiring *wiringModel, options Options) {
+	_, _ = fmt.Fprintln(output, "// Start injects dependencies and calls `AfterInject()` methods of elements, in initialization order.")
+	_, _ = fmt.Fprintln(output, "// If an element fails, initialized elements are released.")
+	_, _ = fmt.Fprintf(output, "func (wiring *%s) Start() error {\n", options.TypeName)
+	_, _ = fmt.Fprintln(output, "\tinitializers := []func() error{")
+	for _, elt := range wiring.initializationOrder {
+		_, _ = fmt.Fprintf(output, "\t\twiring.initialize%s,\n", elt.getterName)
+	}
+	_, _ = fmt.Fprintln(output, "\t}")
+	_, _ = fmt.Fprintln(output, "\tfor index := wiring.initialized; index < len(initializers); index++ {")
+	_, _ = fmt.Fprintln(output, "\t\twiring.initialized++")
+	_, _ = fmt.Fprintln(output, "\t\tif err := initializers[index](); err != nil {")
+	_, _ = fmt.Fprintln(output, "\t\t\t// failed element is already released")
+	_, _ = fmt.Fprintln(output, "\t\t\twiring.initialized = index")
+	_, _ = fmt.Fprintln(output, "\t\t\twiring.Stop()")
+	_, _ = fmt.Fprintln(output, "\t\t\treturn err")
+	_, _ = fmt.Fprintln(output, "\t\t}")
+	_, _ = fmt.Fprintln(output, "\t}")
+	_, _ = fmt.Fprintln(output, "\treturn nil")
+	_, _ = fmt.Fprintln(output, "}")
+	_, _ = fmt.Fprintln(output)
+}
+
+// writeWiringStop writes the Stop method.
+func writeWiringStop(output io.Writer, wiring *wiringModel, options Options) {
+	_, _ = fmt.Fprintln(output, "// Stop calls `Release()` methods of initialized elements and removes dependencies.")
+	_, _ = fmt.Fprintf(output, "func (wiring *%s) Stop() {\n", options.TypeName)
+	_, _ = fmt.Fprintln(output, "\treleasers := []func(){")
+	for _, elt := range wiring.initializationOrder {
+		_, _ = fmt.Fprintf(output, "\t\twiring.release%s,\n", elt.getterName)
+	}
+	_, _ = fmt.Fprintln(output, "\t}")
+	_, _ = fmt.Fprintln(output, "\tfor index := 0; index < wiring.initialized; index++ {")
+	_, _ = fmt.Fprintln(output, "\t\treleasers[index]()")
+	_, _ = fmt.Fprintln(output, "\t}")
+	_, _ = fmt.Fprintln(output, "\twiring.initialized = 0")
+	_, _ = fmt.Fprintln(output, "}")
+	_, _ = fmt.Fprintln(output)
+}
+
+// writeElementInitialization writes the initialization method of element.
+func writeElementInitialization(output io.Writer, elt *element, options Options) {
+	_, _ = fmt.Fprintf(output, "// initialize%s injects dependencies of '%s' element", elt.getterName, elt.name)
+	if elt.initializable {
+		_, _ = fmt.Fprint(output, " and calls `AfterInject()` method")
+	}
+	_, _ = fmt.Fprintln(output, ".")
+	_, _ = fmt.Fprintf(output, "func (wiring *%s) initialize%s() error {\n", options.TypeName, elt.getterName)
+	for _, injected := range elt.injections {
+		dereference := ""
+		if injected.dereference {
+			dereference = "*"
+		}
+		_, _ = fmt.Fprintf(output, "\twiring.elements.%s.%s = %swiring.elements.%s\n",
+			elt.fieldName, injected.path, dereference, injected.dependency.fieldName)
+	}
+	if elt.initializable {
+		_, _ = fmt.Fprintf(output, "\tif err := wiring.elements.%s.AfterInject(); err != nil {\n", elt.fieldName)
+		_, _ = fmt.Fprintf(output, "\t\twiring.release%s()\n", elt.getterName)
+		_, _ = fmt.Fprintf(output, "\t\treturn %s.NewWithCause(err, \"failed to start context, \"+\n", errorsPackageName)
+		_, _ = fmt.Fprintf(output, "\t\t\t\"error during '%%s' element initialization\", %s)\n", strconv.Quote(elt.name))
+		_, _ = fmt.Fprintln(output, "\t}")
+	}
+	_, _ = fmt.Fprintln(output, "\treturn nil")
+	_, _ = fmt.Fprintln(output, "}")
+	_, _ = fmt.Fprintln(output)
+}
+
+// writeElementRelease writes the release method of element.
+// Function returns true if generated code requires the reset function.
+func writeElementRelease(output io.Writer, elt *element, options Options) (resetRequired bool) {
+	_, _ = fmt.Fprintf(output, "// release%s ", elt.getterName)
+	if elt.releasable {
+		_, _ = fmt.Fprint(output, "calls `Release()` method and ")
+	}
+	_, _ = fmt.Fprintf(output, "removes dependencies of '%s' element.\n", elt.name)
+	_, _ = fmt.Fprintf(output, "func (wiring *%s) release%s() {\n", options.TypeName, elt.getterName)
+	if elt.releasable {
+		_, _ = fmt.Fprintf(output, "\twiring.elements.%s.Release()\n", elt.fieldName)
+	}
+	for _, injected := range elt.injections {
+		if isNillable(injected.fieldType) {
+			_, _ = fmt.Fprintf(output, "\twiring.elements.%s.%s = nil\n", elt.fieldName, injected.path)
+		} else {
+			_, _ = fmt.Fprintf(output, "\treset%sValue(&wiring.elements.%s.%s)\n",
+				options.TypeName, elt.fieldName, injected.path)
+			resetRequired = true
+		}
+	}
+	_, _ = fmt.Fprintln(output, "}")
+	_, _ = fmt.Fprintln(output)
+	return resetRequired
+}
+
+// writeResetValue writes the function which assigns zero value to not nillable fields.
+func writeResetValue(output io.Writer, options Options) {
+	_, _ = fmt.Fprintf(output, "// reset%sValue assigns the zero value to the variable.\n", options.TypeName)
+	_, _ = fmt.Fprintf(output, "func reset%sValue[T any](variable *T) {\n", options.TypeName)
+	_, _ = fmt.Fprintln(output, "\tvar zero T")
+	_, _ = fmt.Fprintln(output, "\t*variable = zero")
+	_, _ = fmt.Fprintln(output, "}")
+}
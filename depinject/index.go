@@ -0,0 +1,72 @@
+package depinject
+
+import "reflect"
+
+// elementIndex indexes context elements by name and by type.
+//
+// Elements registered with exposed types (see Context.AddAs) are indexed by these types when they are added.
+// Other elements can be injected in any assignable type: they are checked when a type is searched
+// for the first time, then the result is indexed and updated by each new element.
+type elementIndex struct {
+	// byName contains elements by name.
+	byName map[string][]*elementInformation
+	// byExposedType contains elements registered with exposed types, by exposed type.
+	byExposedType map[reflect.Type][]*elementInformation
+	// implicitElements contains elements registered without exposed types.
+	implicitElements []*elementInformation
+	// byType contains elements matching each searched type.
+	byType map[reflect.Type][]*elementInformation
+}
+
+// newElementIndex creates an empty index.
+func newElementIndex() *elementIndex {
+	return &elementIndex{
+		byName:           make(map[string][]*elementInformation),
+		byExposedType:    make(map[reflect.Type][]*elementInformation),
+		implicitElements: make([]*elementInformation, 0),
+		byType:           make(map[reflect.Type][]*elementInformation),
+	}
+}
+
+// add adds an element to index.
+func (index *elementIndex) add(element *elementInformation) {
+	index.byName[element.name] = append(index.byName[element.name], element)
+	if element.exposedTypes != nil {
+		for _, exposedType := range element.exposedTypes {
+			index.byExposedType[exposedType] = append(index.byExposedType[exposedType], element)
+		}
+	} else {
+		index.implicitElements = append(index.implicitElements, element)
+	}
+	for indexedType, elements := range index.byType {
+		if element.matchType(indexedType) {
+			index.byType[indexedType] = append(elements, element)
+		}
+	}
+}
+
+// findByName returns a copy of the elements with the name.
+func (index *elementIndex) findByName(name string) []*elementInformation {
+	return copyElements(index.byName[name])
+}
+
+// findByType returns a copy of the elements matching the type (see elementInformation.matchType).
+// If type is not yet indexed, only elements registered without exposed types are checked.
+func (index *elementIndex) findByType(eltType reflect.Type) []*elementInformation {
+	finds, ok := index.byType[eltType]
+	if !ok {
+		finds = copyElements(index.byExposedType[eltType])
+		for _, element := range index.implicitElements {
+			if element.matchType(eltType) {
+				finds = append(finds, element)
+			}
+		}
+		index.byType[eltType] = finds
+	}
+	return copyElements(finds)
+}
+
+// copyElements returns a copy of the elements slice: callers cannot modify the index.
+func copyElements(elements []*elementInformation) []*elementInformation {
+	return append(make([]*elementInformation, 0, len(elements)), elements...)
+}
@@ -40,3 +40,43 @@ func findNoPointerType(elemType reflect.Type) reflect.Type {
 		}
 	}
 }
+
+// injectionField is a structure field with dependency injection tag.
+type injectionField struct {
+	// path is the field path from the element structure (example: "BaseService.Logger").
+	path string
+	// field is the structure field description.
+	field reflect.StructField
+}
+
+// findInjectionFields returns all fields with dependency injection tag (see InjectTag) of a structure type.
+// Fields of embedded structures and of nested structures (see InjectNestedTag) are searched recursively.
+// If type is not a structure (or a pointer of structure), return an empty slice.
+func findInjectionFields(elemType reflect.Type) []injectionField {
+	return appendInjectionFields(make([]injectionField, 0), findStructType(elemType), "", map[reflect.Type]bool{})
+}
+
+// appendInjectionFields appends fields with dependency injection tag of the structure type to the fields slice.
+// The argument visitedTypes contains types of the current path, to stop on recursive structures.
+func appendInjectionFields(fields []injectionField, structType reflect.Type, parentPath string,
+	visitedTypes map[reflect.Type]bool) []injectionField {
+	if structType == nil || visitedTypes[structType] {
+		return fields
+	}
+	visitedTypes[structType] = true
+	defer delete(visitedTypes, structType)
+	fieldsNumber := structType.NumField()
+	for fieldIndex := 0; fieldIndex < fieldsNumber; fieldIndex++ {
+		field := structType.Field(fieldIndex)
+		path := field.Name
+		if parentPath != "" {
+			path = parentPath + "." + field.Name
+		}
+		if _, ok := field.Tag.Lookup(InjectTag); ok {
+			fields = append(fields, injectionField{path: path, field: field})
+		} else if _, ok = field.Tag.Lookup(InjectNestedTag); ok || field.Anonymous {
+			fields = appendInjectionFields(fields, findStructType(field.Type), path, visitedTypes)
+		}
+	}
+	return fields
+}
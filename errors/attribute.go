@@ -0,0 +1,90 @@
+package errors
+
+// Attribute is a key/value information attached to an error (example: the identifier of a missing user).
+type Attribute struct {
+	// Key is the attribute name.
+	Key string
+	// Value is the attribute value.
+	Value interface{}
+	// Sensitive is true if the value must be redacted from user-safe views (see SafeAttributes).
+	Sensitive bool
+}
+
+// With returns the error with the attribute. If the error already has the attribute, its value is replaced.
+// Traceable errors are copied with the attribute. Other errors are wrapped (see Wrap function).
+//
+//	err := errors.With(errors.New("failed to load user"), "userId", id)
+func With(err error, key string, value interface{}) error {
+	return withAttribute(err, Attribute{Key: key, Value: value}, 1)
+}
+
+// WithSensitive returns the error with a sensitive attribute (token, password...), redacted from user-safe views.
+// Internal loggers keep the value (see With function).
+func WithSensitive(err error, key string, value interface{}) error {
+	return withAttribute(err, Attribute{Key: key, Value: value, Sensitive: true}, 1)
+}
+
+// withAttribute returns the error with the attribute. If the error already has the attribute, its value is replaced.
+// The argument execStackSkip is the number of stack frames to ascend to find the caller position.
+func withAttribute(err error, attribute Attribute, execStackSkip int) error {
+	if err == nil {
+		return nil
+	}
+	result := copyOrWrapError(err, execStackSkip+1)
+	attributes := make([]Attribute, 0, len(result.attributes)+1)
+	for _, existing := range result.attributes {
+		if existing.Key != attribute.Key {
+			attributes = append(attributes, existing)
+		}
+	}
+	result.attributes = append(attributes, attribute)
+	return result
+}
+
+// AttributesOf returns the attributes of all errors in the error chain, from the error to the root cause.
+// Aggregated errors (`Unwrap() []error` method) are visited depth first, in aggregation order.
+// Attributes of errors of other packages are read with Attributer interface.
+// If an attribute is defined several times, the value of the first visited error is kept.
+func AttributesOf(err error) []Attribute {
+	attributes := make([]Attribute, 0)
+	keys := make(map[string]bool)
+	collectAttributes(err, func(attribute Attribute) {
+		if !keys[attribute.Key] {
+			keys[attribute.Key] = true
+			attributes = append(attributes, attribute)
+		}
+	})
+	return attributes
+}
+
+// collectAttributes visits the error chain depth first (see Walk function),
+// and calls collect function with the attributes of every error.
+func collectAttributes(err error, collect func(attribute Attribute)) {
+	Walk(err, func(member error) bool {
+		for _, attribute := range ownAttributes(member) {
+			collect(attribute)
+		}
+		return false
+	})
+}
+
+// ownAttributes returns the attributes of the error, without causes attributes if error is defined in this package.
+func ownAttributes(err error) []Attribute {
+	switch typedErr := err.(type) {
+	case *customError:
+		return typedErr.attributes
+	case *FieldError:
+		return typedErr.customError.attributes
+	case *MultiError, *ValidationError:
+		return nil
+	case Attributer:
+		return typedErr.Attributes()
+	default:
+		return nil
+	}
+}
+
+// Attributes method returns the attributes of the error and of its causes (see AttributesOf function).
+func (err *customError) Attributes() []Attribute {
+	return AttributesOf(err)
+}
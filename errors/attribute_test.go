@@ -0,0 +1,86 @@
+package errors
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// attributedError is an error of another package implementing Attributer interface.
+type attributedError struct{}
+
+func (attributedError) Error() string { return "attributed error" }
+
+func (attributedError) Attributes() []Attribute {
+	return []Attribute{{Key: "foreign", Value: "value"}}
+}
+
+// validationWithAttribute returns a validation error with an attribute on its field error.
+func validationWithAttribute() error {
+	validation := NewValidationError()
+	validation.AddError("name", With(New("name is too long"), "maxLength", 20))
+	return validation
+}
+
+func TestWith(t *testing.T) {
+	root := With(With(New("user not found"), "userId", 42), "source", "database")
+	tests := []struct {
+		name string
+		err  error
+		want []Attribute
+	}{
+		{
+			name: "error attributes",
+			err:  root,
+			want: []Attribute{{Key: "userId", Value: 42}, {Key: "source", Value: "database"}},
+		},
+		{
+			name: "replaced attribute",
+			err:  With(root, "userId", 43),
+			want: []Attribute{{Key: "source", Value: "database"}, {Key: "userId", Value: 43}},
+		},
+		{
+			name: "cause chain attributes",
+			err:  With(NewWithCause(fmt.Errorf("load: %w", root), "failed to load"), "userId", 7),
+			want: []Attribute{{Key: "userId", Value: 7}, {Key: "source", Value: "database"}},
+		},
+		{
+			name: "standard error",
+			err:  With(fmt.Errorf("standard error"), "retry", true),
+			want: []Attribute{{Key: "retry", Value: true}},
+		},
+		{
+			name: "aggregated errors attributes",
+			err:  Append(With(New("first error"), "userId", 1), With(New("second error"), "tenant", "acme")),
+			want: []Attribute{{Key: "userId", Value: 1}, {Key: "tenant", Value: "acme"}},
+		},
+		{
+			name: "field errors attributes",
+			err:  validationWithAttribute(),
+			want: []Attribute{{Key: "maxLength", Value: 20}},
+		},
+		{
+			name: "foreign attributes",
+			err:  fmt.Errorf("wrapped: %w", attributedError{}),
+			want: []Attribute{{Key: "foreign", Value: "value"}},
+		},
+		{
+			name: "without attribute",
+			err:  fmt.Errorf("standard error"),
+			want: []Attribute{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AttributesOf(tt.err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AttributesOf() = '%v', want '%v'", got, tt.want)
+			}
+			if traceable, ok := tt.err.(Attributer); ok && !reflect.DeepEqual(traceable.Attributes(), tt.want) {
+				t.Errorf("Attributes() = '%v', want '%v'", traceable.Attributes(), tt.want)
+			}
+		})
+	}
+	if With(nil, "userId", 42) != nil {
+		t.Errorf("With(nil) != nil")
+	}
+}
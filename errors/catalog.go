@@ -0,0 +1,103 @@
+package errors
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// catalog contains the declared error definitions, by code.
+var catalog = struct {
+	mutex       sync.RWMutex
+	definitions map[Code]*Definition
+}{definitions: make(map[Code]*Definition)}
+
+// Definition is a declared error: a code and a message template.
+// Errors are created from the definition with the template arguments (see Definition.New).
+//
+// Definition implements error interface: `errors.Is(err, definition)` (standard errors package)
+// returns true if an error of the chain has the definition code.
+type Definition struct {
+	// code is the error code.
+	code Code
+	// format is the message template (see fmt.Sprintf method format).
+	format string
+	// kind is the error category.
+	kind Kind
+}
+
+// Define declares an error definition in the catalog. Function panics if the code is already declared.
+//
+//	var ErrUserNotFound = errors.Define("USR-404", "user %s not found").WithKind(errors.KindNotFound)
+func Define(code Code, format string) *Definition {
+	catalog.mutex.Lock()
+	defer catalog.mutex.Unlock()
+	if _, found := catalog.definitions[code]; found {
+		panic(fmt.Sprintf("error definition '%s' is already declared", code))
+	}
+	definition := &Definition{code: code, format: format}
+	catalog.definitions[code] = definition
+	return definition
+}
+
+// Catalog returns the declared error definitions, sorted by code.
+func Catalog() []*Definition {
+	catalog.mutex.RLock()
+	defer catalog.mutex.RUnlock()
+	definitions := make([]*Definition, 0, len(catalog.definitions))
+	for _, definition := range catalog.definitions {
+		definitions = append(definitions, definition)
+	}
+	sort.Slice(definitions, func(i, j int) bool {
+		return definitions[i].code < definitions[j].code
+	})
+	return definitions
+}
+
+// WithKind sets the kind of errors created from the definition, and returns the definition.
+func (definition *Definition) WithKind(kind Kind) *Definition {
+	definition.kind = kind
+	return definition
+}
+
+// Code returns the definition code.
+func (definition *Definition) Code() Code {
+	return definition.code
+}
+
+// Format returns the message template.
+func (definition *Definition) Format() string {
+	return definition.format
+}
+
+// Kind returns the kind of errors created from the definition.
+func (definition *Definition) Kind() Kind {
+	return definition.kind
+}
+
+// Error returns the definition code and message template.
+func (definition *Definition) Error() string {
+	return fmt.Sprintf("[%s] %s", definition.code, definition.format)
+}
+
+// New creates an error from the definition, at the caller position.
+// The arguments complete the message template. The definition code is the message key (see AddMessages).
+func (definition *Definition) New(attributes ...interface{}) error {
+	return definition.build(nil, attributes)
+}
+
+// NewWithCause creates an error with a cause from the definition, at the caller position.
+// The arguments complete the message template.
+func (definition *Definition) NewWithCause(cause error, attributes ...interface{}) error {
+	return definition.build(cause, attributes)
+}
+
+// build creates an error from the definition, at the position of the caller of New or NewWithCause methods.
+func (definition *Definition) build(cause error, attributes []interface{}) error {
+	// skip 2 -> ignore build(...) and New(...) or NewWithCause(...) methods.
+	err := extractPositionAndBuildCustomError(cause, 2, definition.format, attributes...).(*customError)
+	err.key = string(definition.code)
+	err.code = definition.code
+	err.kind = definition.kind
+	return err
+}
@@ -0,0 +1,75 @@
+package errors
+
+import (
+	goerr "errors"
+	"fmt"
+	"testing"
+)
+
+var errTestUserNotFound = Define("TEST-404", "user %s not found").WithKind(KindNotFound)
+
+var errTestUserConflict = Define("TEST-409", "user %s already exists")
+
+func TestDefinition_New(t *testing.T) {
+	cause := fmt.Errorf("no rows")
+	tests := []struct {
+		name        string
+		err         error
+		wantMessage string
+		wantCause   error
+	}{
+		{
+			name:        "new error",
+			err:         errTestUserNotFound.New("john"),
+			wantMessage: "user john not found",
+		},
+		{
+			name:        "new error with cause",
+			err:         errTestUserNotFound.NewWithCause(cause, "jane"),
+			wantMessage: "user jane not found",
+			wantCause:   cause,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			traceable := tt.err.(*customError)
+			if traceable.Message() != tt.wantMessage || traceable.Cause() != tt.wantCause {
+				t.Errorf("New() = '%v', want '%v'", traceable.Message(), tt.wantMessage)
+			}
+			if traceable.Code() != "TEST-404" || traceable.Kind() != KindNotFound {
+				t.Errorf("New() code = '%v', kind = '%v'", traceable.Code(), traceable.Kind())
+			}
+			if traceable.Function() != "github.com/deverdeb/bvmgo-util/errors.TestDefinition_New" {
+				t.Errorf("New() function = '%v', want TestDefinition_New", traceable.Function())
+			}
+			if !goerr.Is(tt.err, errTestUserNotFound) || goerr.Is(tt.err, errTestUserConflict) {
+				t.Errorf("Is() must match the error definition only")
+			}
+		})
+	}
+}
+
+func TestDefine_duplicate(t *testing.T) {
+	defer func() {
+		if recovered := recover(); recovered != "error definition 'TEST-404' is already declared" {
+			t.Errorf("Define() panic = '%v', want duplicate definition panic", recovered)
+		}
+	}()
+	Define("TEST-404", "duplicate")
+}
+
+func TestCatalog(t *testing.T) {
+	definitions := make([]*Definition, 0)
+	for _, definition := range Catalog() {
+		if definition == errTestUserNotFound || definition == errTestUserConflict {
+			definitions = append(definitions, definition)
+		}
+	}
+	if len(definitions) != 2 || definitions[0] != errTestUserNotFound || definitions[1] != errTestUserConflict {
+		t.Errorf("Catalog() = '%v', want sorted test definitions", definitions)
+	}
+	if errTestUserConflict.Code() != "TEST-409" || errTestUserConflict.Format() != "user %s already exists" ||
+		errTestUserConflict.Kind() != KindUnknown || errTestUserConflict.Error() != "[TEST-409] user %s already exists" {
+		t.Errorf("Definition = '%v'", errTestUserConflict)
+	}
+}
@@ -0,0 +1,75 @@
+package errors
+
+import (
+	"io"
+	"reflect"
+)
+
+// CloseAndJoin closes the closer and merges the close error into the error, at the caller position.
+// Function is used in `defer` with a named return error. Nil closer is ignored,
+// typed nil closers included (example: `(*os.File)(nil)` of a failed os.Open call).
+//
+//	func read(filename string) (content []byte, err error) {
+//		file, err := os.Open(filename)
+//		if err != nil {
+//			return nil, err
+//		}
+//		defer errors.CloseAndJoin(&err, file)
+//		return io.ReadAll(file)
+//	}
+//
+// The close error never hides the error: see Deferred function.
+func CloseAndJoin(err *error, closer io.Closer) {
+	if isNil(closer) {
+		return
+	}
+	joinCleanupError(err, closer.Close(), 1, "failed to close resource")
+}
+
+// Deferred calls the cleanup function and merges the cleanup error into the error, at the caller position.
+// Function is used in `defer` with a named return error.
+//
+//	defer errors.Deferred(&err, transaction.Rollback)
+//
+// If the error is nil, it is replaced by the cleanup error (as cause).
+// Otherwise, the error and the cleanup error are aggregated in a MultiError (see Append function),
+// the error stays the first member.
+func Deferred(err *error, cleanup func() error) {
+	if cleanup == nil {
+		return
+	}
+	joinCleanupError(err, cleanup(), 1, "deferred cleanup failed")
+}
+
+// isNil returns true if the value is nil, or is a nil pointer, map, slice, function or channel in an interface.
+func isNil(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.Interface, reflect.UnsafePointer:
+		return reflected.IsNil()
+	default:
+		return false
+	}
+}
+
+// joinCleanupError merges the cleanup error into the error. Nil cleanup error is ignored.
+// An aggregated error (*MultiError) is copied with the cleanup error: it is not modified.
+// The argument execStackSkip is the number of stack frames to ascend to find the caller position.
+func joinCleanupError(err *error, cleanupErr error, execStackSkip int, message string) {
+	if cleanupErr == nil {
+		return
+	}
+	wrapper := extractPositionAndBuildCustomError(cleanupErr, execStackSkip+1, message).(*customError)
+	if *err == nil {
+		*err = wrapper
+	} else if multiError, ok := (*err).(*MultiError); ok && multiError != nil {
+		copied := *multiError
+		copied.errors = append(append(make([]error, 0, len(multiError.errors)+1), multiError.errors...), wrapper)
+		*err = &copied
+	} else {
+		*err = &MultiError{errors: []error{*err, wrapper}, file: wrapper.file, function: wrapper.function, line: wrapper.line}
+	}
+}
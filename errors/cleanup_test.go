@@ -0,0 +1,108 @@
+package errors
+
+import (
+	goerr "errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+// testCloser is a closer which returns an error.
+type testCloser struct {
+	err    error
+	closed bool
+}
+
+func (closer *testCloser) Close() error {
+	closer.closed = true
+	return closer.err
+}
+
+// closeWithPrimary returns the primary error and closes the closer.
+func closeWithPrimary(primary error, closer *testCloser) (err error) {
+	defer CloseAndJoin(&err, closer)
+	return primary
+}
+
+func TestCloseAndJoin(t *testing.T) {
+	primary := WithCode(New("read failed"), "READ")
+	closeErr := fmt.Errorf("disk full")
+	tests := []struct {
+		name        string
+		primary     error
+		closeErr    error
+		wantNil     bool
+		wantMembers int
+	}{
+		{name: "no error", primary: nil, closeErr: nil, wantNil: true},
+		{name: "primary error only", primary: primary, closeErr: nil, wantMembers: 0},
+		{name: "close error only", primary: nil, closeErr: closeErr, wantMembers: 0},
+		{name: "primary and close errors", primary: primary, closeErr: closeErr, wantMembers: 2},
+		{name: "aggregated primary errors", primary: Append(primary, fmt.Errorf("other")), closeErr: closeErr, wantMembers: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			closer := &testCloser{err: tt.closeErr}
+			err := closeWithPrimary(tt.primary, closer)
+			if !closer.closed {
+				t.Errorf("CloseAndJoin() did not close the closer")
+			}
+			if tt.wantNil {
+				if err != nil {
+					t.Errorf("CloseAndJoin() error = '%v', want nil", err)
+				}
+				return
+			}
+			if tt.primary != nil && !goerr.Is(err, primary) {
+				t.Errorf("CloseAndJoin() error = '%v', primary error is hidden", err)
+			}
+			if tt.closeErr != nil && !goerr.Is(err, closeErr) {
+				t.Errorf("CloseAndJoin() error = '%v', close error is missing", err)
+			}
+			members := 0
+			if multiError, ok := err.(*MultiError); ok {
+				members = len(multiError.Errors())
+			}
+			if members != tt.wantMembers {
+				t.Errorf("CloseAndJoin() has %d aggregated errors, want %d", members, tt.wantMembers)
+			}
+		})
+	}
+}
+
+func TestCloseAndJoin_typedNilCloser(t *testing.T) {
+	run := func() (err error) {
+		var file *os.File
+		defer CloseAndJoin(&err, file)
+		return nil
+	}
+	if err := run(); err != nil {
+		t.Errorf("CloseAndJoin() error = '%v', want nil for typed nil closer", err)
+	}
+}
+
+func TestCloseAndJoin_doesNotModifyAggregatedError(t *testing.T) {
+	primary := Append(nil, fmt.Errorf("first"))
+	err := closeWithPrimary(primary, &testCloser{err: fmt.Errorf("disk full")})
+	if len(primary.(*MultiError).Errors()) != 1 || len(err.(*MultiError).Errors()) != 2 {
+		t.Errorf("CloseAndJoin() error = '%v', primary error = '%v', want primary error unchanged", err, primary)
+	}
+}
+
+func TestDeferred(t *testing.T) {
+	cleanupErr := fmt.Errorf("rollback failed")
+	run := func() (err error) {
+		defer Deferred(&err, func() error { return cleanupErr })
+		defer Deferred(&err, nil)
+		return nil
+	}
+	err := run()
+	traceable, ok := err.(*customError)
+	if !ok || !goerr.Is(err, cleanupErr) || traceable.Message() != "deferred cleanup failed" {
+		t.Fatalf("Deferred() error = '%v', want cleanup error as cause", err)
+	}
+	if !strings.HasSuffix(traceable.Function(), "TestDeferred.func1") {
+		t.Errorf("Deferred() function = '%s', want 'TestDeferred.func1'", traceable.Function())
+	}
+}
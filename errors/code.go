@@ -0,0 +1,92 @@
+package errors
+
+import (
+	goerr "errors"
+)
+
+// Code is a machine-readable error code (example: "USER_NOT_FOUND").
+//
+// Code implements error interface: `errors.Is(err, code)` (standard errors package)
+// returns true if an error of the chain has the code.
+type Code string
+
+// Error returns the code.
+func (code Code) Error() string {
+	return string(code)
+}
+
+// Kind is an error category.
+type Kind string
+
+const (
+	// KindUnknown is the kind of errors without category.
+	KindUnknown Kind = ""
+	// KindNotFound is the kind of errors caused by a missing resource.
+	KindNotFound Kind = "not_found"
+	// KindConflict is the kind of errors caused by a conflict with the resource state.
+	KindConflict Kind = "conflict"
+	// KindInvalidInput is the kind of errors caused by invalid arguments.
+	KindInvalidInput Kind = "invalid_input"
+	// KindUnavailable is the kind of errors caused by an unavailable service.
+	KindUnavailable Kind = "unavailable"
+)
+
+// WithCode returns the error with the code.
+// Traceable errors are copied with the code. Other errors are wrapped (see Wrap function).
+func WithCode(err error, code Code) error {
+	if err == nil {
+		return nil
+	}
+	result := copyOrWrapError(err, 1)
+	result.code = code
+	return result
+}
+
+// WithKind returns the error with the kind.
+// Traceable errors are copied with the kind. Other errors are wrapped (see Wrap function).
+func WithKind(err error, kind Kind) error {
+	if err == nil {
+		return nil
+	}
+	result := copyOrWrapError(err, 1)
+	result.kind = kind
+	return result
+}
+
+// CodeOf returns the first code found in the error chain, or an empty code if no error has a code.
+func CodeOf(err error) Code {
+	for ; err != nil; err = goerr.Unwrap(err) {
+		if coded, ok := err.(Coder); ok && coded.Code() != "" {
+			return coded.Code()
+		}
+	}
+	return ""
+}
+
+// KindOf returns the first kind found in the error chain, or KindUnknown if no error has a kind.
+func KindOf(err error) Kind {
+	for ; err != nil; err = goerr.Unwrap(err) {
+		if kinded, ok := err.(Kinder); ok && kinded.Kind() != KindUnknown {
+			return kinded.Kind()
+		}
+	}
+	return KindUnknown
+}
+
+// Is method returns true if target is the error code, an error definition or an error with the same code.
+// Method is used by `errors.Is` function of standard errors package.
+func (err *customError) Is(target error) bool {
+	if err.code == "" {
+		return false
+	}
+	switch typedTarget := target.(type) {
+	case Code:
+		return typedTarget == err.code
+	case *customError:
+		return typedTarget.code == err.code
+	case *Definition:
+		return typedTarget.code == err.code
+	default:
+		return false
+	}
+}
@@ -0,0 +1,89 @@
+package errors
+
+import (
+	goerr "errors"
+	"fmt"
+	"testing"
+)
+
+const codeUserNotFound Code = "USER_NOT_FOUND"
+
+func TestWithCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		target   error
+		wantIs   bool
+		wantCode Code
+	}{
+		{
+			name:     "match code",
+			err:      WithCode(New("user not found"), codeUserNotFound),
+			target:   codeUserNotFound,
+			wantIs:   true,
+			wantCode: codeUserNotFound,
+		},
+		{
+			name:     "match code of wrapped error",
+			err:      NewWithCause(Wrap(WithCode(New("user not found"), codeUserNotFound)), "failed to load user"),
+			target:   codeUserNotFound,
+			wantIs:   true,
+			wantCode: codeUserNotFound,
+		},
+		{
+			name:     "match code of standard error",
+			err:      fmt.Errorf("failed to load user: %w", WithCode(fmt.Errorf("user not found"), codeUserNotFound)),
+			target:   codeUserNotFound,
+			wantIs:   true,
+			wantCode: codeUserNotFound,
+		},
+		{
+			name:     "match error with same code",
+			err:      WithCode(New("user not found"), codeUserNotFound),
+			target:   WithCode(New("another user not found"), codeUserNotFound),
+			wantIs:   true,
+			wantCode: codeUserNotFound,
+		},
+		{
+			name:     "different code",
+			err:      WithCode(New("user conflict"), "USER_CONFLICT"),
+			target:   codeUserNotFound,
+			wantIs:   false,
+			wantCode: "USER_CONFLICT",
+		},
+		{
+			name:     "no code",
+			err:      New("user not found"),
+			target:   codeUserNotFound,
+			wantIs:   false,
+			wantCode: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := goerr.Is(tt.err, tt.target); got != tt.wantIs {
+				t.Errorf("Is() = %v, want %v", got, tt.wantIs)
+			}
+			if got := CodeOf(tt.err); got != tt.wantCode {
+				t.Errorf("CodeOf() = '%v', want '%v'", got, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestWithKind(t *testing.T) {
+	err := WithKind(WithCode(New("user not found"), codeUserNotFound), KindNotFound)
+	wrapped := Wrap(err).(*customError)
+	if wrapped.Code() != codeUserNotFound || wrapped.Kind() != KindNotFound {
+		t.Errorf("Wrap() code = '%v', kind = '%v', want '%v' and '%v'", wrapped.Code(), wrapped.Kind(), codeUserNotFound, KindNotFound)
+	}
+	if got := KindOf(NewWithCause(err, "failed to load user")); got != KindNotFound {
+		t.Errorf("KindOf() = '%v', want '%v'", got, KindNotFound)
+	}
+	if got := KindOf(fmt.Errorf("standard error")); got != KindUnknown {
+		t.Errorf("KindOf() = '%v', want '%v'", got, KindUnknown)
+	}
+	if WithKind(nil, KindNotFound) != nil || WithCode(nil, codeUserNotFound) != nil {
+		t.Errorf("WithKind(nil) and WithCode(nil) must return nil")
+	}
+}
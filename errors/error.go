@@ -2,6 +2,7 @@ package errors
 
 import (
 	"fmt"
+	"reflect"
 	"runtime"
 )
 
@@ -27,10 +28,41 @@ type TraceableError interface {
 	Cause() error
 }
 
+// StackTracer is an error with the call stack of its creation (optional interface of traceable errors).
+type StackTracer interface {
+	// StackTrace method returns the call stack of error creation.
+	// It is nil if stack trace was not captured (see EnableStackTrace and WithStack functions).
+	StackTrace() *StackTrace
+}
+
+// Coder is an error with a machine-readable code (optional interface of traceable errors).
+type Coder interface {
+	// Code method returns the machine-readable error code, or an empty code if error has no code.
+	Code() Code
+}
+
+// Kinder is an error with a category (optional interface of traceable errors).
+type Kinder interface {
+	// Kind method returns the error category, or KindUnknown.
+	Kind() Kind
+}
+
+// Attributer is an error with attributes (optional interface of traceable errors).
+type Attributer interface {
+	// Attributes method returns the attributes of the error and of its causes.
+	Attributes() []Attribute
+}
+
 // customError is an error with cause.
 type customError struct {
-	// message is the error label.
+	// message is the error label, formatted when error is created.
 	message string
+	// key is the message key in message catalogs (see AddMessages). It is empty if message is not localizable.
+	key string
+	// format is the format of error message. It is empty if error has no message format.
+	format string
+	// arguments are the arguments to complete the localized error messages (see snapshotArguments).
+	arguments []interface{}
 	// cause is the wrapped error.
 	cause error
 	// file is the filename.
@@ -39,6 +71,16 @@ type customError struct {
 	function string
 	// line is the line in file.
 	line int
+	// stack is the call stack of error creation. It is nil if stack trace was not captured.
+	stack *StackTrace
+	// code is the machine-readable error code. It is empty if error has no code.
+	code Code
+	// kind is the error category.
+	kind Kind
+	// attributes contains the attributes of error, without causes attributes.
+	attributes []Attribute
+	// public is the user-safe message of error, without causes public messages. Empty if not defined.
+	public string
 }
 
 // NewWithCause build a new error with a cause and a message.
@@ -46,18 +88,41 @@ func NewWithCause(cause error, format string, attributes ...interface{}) error {
 	return extractPositionAndBuildCustomError(cause, 1, format, attributes...)
 }
 
+// NewWithCauseSkip build a new error with a cause and a message, at the position of a caller.
+// The argument skip is the number of stack frames to ascend, with 0 identifying the caller of NewWithCauseSkip:
+// helper functions use 1 to record the position of their own caller.
+func NewWithCauseSkip(skip int, cause error, format string, attributes ...interface{}) error {
+	return extractPositionAndBuildCustomError(cause, skip+1, format, attributes...)
+}
+
 // New build a new error with a message.
 func New(format string, attributes ...interface{}) error {
 	return extractPositionAndBuildCustomError(nil, 1, format, attributes...)
 }
 
 // Wrap build a new error with the cause (only add the stack information).
+// Code and kind of traceable cause are kept.
 func Wrap(cause error) error {
 	err, ok := cause.(TraceableError)
 	if ok {
-		return extractPositionAndBuildCustomError(cause, 1, err.Message())
+		// Cause message is an argument: it is not a format (example: message with '%' character).
+		wrapper := extractPositionAndBuildCustomError(cause, 1, "%s", err.Message()).(*customError)
+		wrapper.key = ""
+		if custom, ok := cause.(*customError); ok {
+			// Keep message key and arguments for localization.
+			wrapper.key, wrapper.format, wrapper.arguments = custom.key, custom.format, custom.arguments
+		}
+		if coded, ok := cause.(Coder); ok {
+			wrapper.code = coded.Code()
+		}
+		if kinded, ok := cause.(Kinder); ok {
+			wrapper.kind = kinded.Kind()
+		}
+		return wrapper
 	} else {
-		return extractPositionAndBuildCustomError(cause, 1, cause.Error())
+		wrapper := extractPositionAndBuildCustomError(cause, 1, "%s", cause.Error()).(*customError)
+		wrapper.key = ""
+		return wrapper
 	}
 
 }
@@ -70,17 +135,38 @@ func Wrap(cause error) error {
 // The argument attributes are the arguments to complete the error message (see fmt.Sprintf method format).
 func extractPositionAndBuildCustomError(cause error, execStackSkip int, format string, attributes ...interface{}) error {
 	file, function, line := extractPositionInExecutionStack(execStackSkip + 1) // +1 to skip current method.
-	return buildCustomError(cause, file, function, line, format, attributes...)
+	err := buildCustomError(cause, file, function, line, format, attributes...)
+	if IsStackTraceEnabled() {
+		err.(*customError).stack = captureStackTrace(execStackSkip + 1)
+	}
+	return err
+}
+
+// copyOrWrapError returns a copy of traceable error, or a new error wrapping the other errors.
+// The argument skip is the number of stack frames to ascend, with 0 identifying the caller.
+func copyOrWrapError(err error, execStackSkip int) *customError {
+	if traceable, ok := err.(*customError); ok {
+		copied := *traceable
+		return &copied
+	}
+	wrapper := extractPositionAndBuildCustomError(err, execStackSkip+1, "%s", err.Error()).(*customError)
+	wrapper.key = ""
+	return wrapper
 }
 
 // buildCustomError return a new error.
+// The format is the message key (see AddMessages).
+// Message is formatted when error is created: later changes of mutable arguments do not change it.
 func buildCustomError(cause error, file string, function string, line int, format string, attributes ...interface{}) error {
 	return &customError{
-		message:  fmt.Sprintf(format, attributes...),
-		cause:    cause,
-		file:     file,
-		function: function,
-		line:     line,
+		message:   fmt.Sprintf(format, attributes...),
+		key:       format,
+		format:    format,
+		arguments: snapshotArguments(attributes),
+		cause:     cause,
+		file:      file,
+		function:  function,
+		line:      line,
 	}
 }
 
@@ -91,19 +177,41 @@ func (err *customError) Error() (message string) {
 	if err.cause != nil {
 		cause = "\n    > cause by: " + err.cause.Error()
 	}
-	position := ""
-	if err.line > 0 {
-		if err.function == unknownFunctionLabel {
-			position = fmt.Sprintf(" ( at %s:%d )", err.file, err.line)
-		} else {
-			position = fmt.Sprintf(" ( at %s:%d )", err.function, err.line)
+	return err.Message() + err.position() + cause
+}
+
+// position returns the error position description, or an empty string if position is unknown.
+func (err *customError) position() string {
+	return formatPosition(err.file, err.function, err.line)
+}
+
+// formatPosition returns the position description, or an empty string if position is unknown.
+func formatPosition(file string, function string, line int) string {
+	if line <= 0 {
+		return ""
+	}
+	return " ( at " + FormatPosition(file, function, line, PositionFunction) + " )"
+}
+
+// snapshotArguments returns a copy of message arguments, where mutable arguments (pointers, maps and slices)
+// are replaced by their `fmt.Sprint` representation: localized messages describe arguments at error creation.
+func snapshotArguments(arguments []interface{}) []interface{} {
+	if len(arguments) == 0 {
+		return arguments
+	}
+	snapshot := make([]interface{}, len(arguments))
+	for index, argument := range arguments {
+		switch reflect.ValueOf(argument).Kind() {
+		case reflect.Ptr, reflect.Map, reflect.Slice:
+			snapshot[index] = fmt.Sprint(argument)
+		default:
+			snapshot[index] = argument
 		}
 	}
-	return err.message + position + cause
+	return snapshot
 }
 
 // Message returns only the error message.
-// If error has cause, add the cause message.
 func (err *customError) Message() (message string) {
 	return err.message
 }
@@ -133,23 +241,40 @@ func (err *customError) Cause() error {
 	return err.cause
 }
 
+// StackTrace method returns the call stack of error creation.
+func (err *customError) StackTrace() *StackTrace {
+	return err.stack
+}
+
 // extractPositionInExecutionStack returns the execution position (file, function and line).
 // The argument skip is the number of stack frames to ascend, with 0 identifying the caller of extractPositionInExecutionStack.
+//
+// Position is resolved with runtime.Callers(...) and runtime.CallersFrames(...): inlined functions
+// (example: error built in a composite literal, or with a method of an error definition) are reported
+// as their own frames.
 func extractPositionInExecutionStack(skip int) (file string, function string, line int) {
-	// Extract element - skip +1 -> ignore extractPositionInExecutionStack(...)
-	ptrExec, file, line, ok := runtime.Caller(skip + 1)
-	if ok {
-		execFrames := runtime.CallersFrames([]uintptr{ptrExec})
-		if execFrames != nil {
-			frame, _ := execFrames.Next()
-			return frame.File, frame.Function, frame.Line
-		} else {
-			// No frame... return unknown function
-			return file, unknownFunctionLabel, line
-		}
-	} else {
-		// Error to extract execution stack.
-		// Return default runtime.Caller(...) values and unknown function
-		return file, unknownFunctionLabel, line
+	// Several program counters: the frame of an inlined function may need the next program counters.
+	programCounters := make([]uintptr, 8)
+	// skip +2 -> ignore runtime.Callers(...) and extractPositionInExecutionStack(...)
+	count := runtime.Callers(skip+2, programCounters)
+	if count == 0 {
+		// Error to extract execution stack... return unknown function
+		return "", unknownFunctionLabel, 0
+	}
+	frame, _ := runtime.CallersFrames(programCounters[:count]).Next()
+	if frame.Function == "" {
+		// No function... return unknown function
+		return frame.File, unknownFunctionLabel, frame.Line
 	}
+	return frame.File, frame.Function, frame.Line
+}
+
+// Code method returns the machine-readable error code.
+func (err *customError) Code() Code {
+	return err.code
+}
+
+// Kind method returns the error category.
+func (err *customError) Kind() Kind {
+	return err.kind
 }
@@ -22,7 +22,7 @@ func TestErrorNew(t *testing.T) {
 				format:     "my %s error",
 				attributes: []interface{}{"test"},
 			},
-			wantMessage: "my test error ( at github.com/deverdeb/bvmgo-util/errors.TestErrorNew.func1:29 )",
+			wantMessage: "my test error ( at github.com/deverdeb/bvmgo-util/errors.TestErrorNew.func1:30 )",
 		},
 	}
 	for _, tt := range tests {
@@ -52,7 +52,7 @@ func TestErrorNewWithCause(t *testing.T) {
 				format:     "my %s error",
 				attributes: []interface{}{"test"},
 			},
-			wantMessage: "my test error ( at github.com/deverdeb/bvmgo-util/errors.TestErrorNewWithCause.func1:60 )\n" +
+			wantMessage: "my test error ( at github.com/deverdeb/bvmgo-util/errors.TestErrorNewWithCause.func1:61 )\n" +
 				"    > cause by: cause error",
 		},
 	}
@@ -136,7 +136,7 @@ func TestErrorWrap(t *testing.T) {
 				cause: New("error message"),
 			},
 			wantMessage: "error message ( at github.com/deverdeb/bvmgo-util/errors.TestErrorWrap.func1:144 )\n" +
-				"    > cause by: error message ( at github.com/deverdeb/bvmgo-util/errors.TestErrorWrap:134 )",
+				"    > cause by: error message ( at github.com/deverdeb/bvmgo-util/errors.TestErrorWrap:136 )",
 		},
 	}
 	for _, tt := range tests {
@@ -184,7 +184,30 @@ func TestError_Function(t *testing.T) {
 
 func TestError_Line(t *testing.T) {
 	err := New("error message").(TraceableError)
-	if err.Line() != 185 {
-		t.Errorf("Line() = '%v', want '%v'", err.Line(), 185)
+	if err.Line() != 186 {
+		t.Errorf("Line() = '%v', want '%v'", err.Line(), 186)
+	}
+}
+
+// newInHelper builds an error at the position of its caller.
+func newInHelper(cause error) error {
+	return NewWithCauseSkip(1, cause, "helper error")
+}
+
+func TestNewWithCauseSkip(t *testing.T) {
+	err := newInHelper(fmt.Errorf("cause error")).(TraceableError)
+	if err.Function() != "github.com/deverdeb/bvmgo-util/errors.TestNewWithCauseSkip" || err.Cause() == nil {
+		t.Errorf("NewWithCauseSkip() position = '%v:%v', want caller of helper", err.Function(), err.Line())
+	}
+}
+
+func TestErrorWrap_formatCharacters(t *testing.T) {
+	err := Wrap(fmt.Errorf("progress is 100%%d")).(TraceableError)
+	if err.Message() != "progress is 100%d" {
+		t.Errorf("Wrap() message = '%v', want 'progress is 100%%d'", err.Message())
+	}
+	wrapped := Wrap(err).(TraceableError)
+	if wrapped.Message() != "progress is 100%d" {
+		t.Errorf("Wrap() message = '%v', want 'progress is 100%%d'", wrapped.Message())
 	}
 }
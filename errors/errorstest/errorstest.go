@@ -0,0 +1,144 @@
+// Package errorstest provides tests assertions on error chains.
+//
+// Assertions check every error of the chain, aggregated errors included (`Unwrap() []error` method).
+// They report failures with the formatted chain, and return true if the assertion succeeds.
+//
+//	err := service.Load("unknown")
+//	errorstest.ContainsCode(t, err, "USR-404")
+//	errorstest.ContainsPosition(t, err, "service.Load")
+package errorstest
+
+import (
+	goerr "errors"
+	"fmt"
+	"github.com/deverdeb/bvmgo-util/errors"
+	"strings"
+	"testing"
+)
+
+// ContainsMessage asserts that an error of the chain has a message containing the substring.
+func ContainsMessage(t testing.TB, err error, substring string) bool {
+	t.Helper()
+	if errors.Walk(err, func(member error) bool { return strings.Contains(message(member), substring) }) {
+		return true
+	}
+	t.Errorf("error chain does not contain message '%s':\n%s", substring, FormatChain(err))
+	return false
+}
+
+// ContainsCause asserts that the chain contains the cause (see `errors.Is` function of standard errors package).
+func ContainsCause(t testing.TB, err error, cause error) bool {
+	t.Helper()
+	if goerr.Is(err, cause) {
+		return true
+	}
+	t.Errorf("error chain does not contain cause '%v':\n%s", cause, FormatChain(err))
+	return false
+}
+
+// ContainsCode asserts that an error of the chain has the code.
+func ContainsCode(t testing.TB, err error, code errors.Code) bool {
+	t.Helper()
+	if errors.Walk(err, func(member error) bool {
+		coded, ok := member.(errors.Coder)
+		return ok && coded.Code() == code
+	}) {
+		return true
+	}
+	t.Errorf("error chain does not contain code '%s':\n%s", code, FormatChain(err))
+	return false
+}
+
+// ContainsType asserts that an error of the chain has the type T, and returns the first error of type T.
+//
+//	fieldError, ok := errorstest.ContainsType[*errors.FieldError](t, err)
+func ContainsType[T error](t testing.TB, err error) (T, bool) {
+	t.Helper()
+	var result T
+	found := errors.Walk(err, func(member error) bool {
+		typed, ok := member.(T)
+		if ok {
+			result = typed
+		}
+		return ok
+	})
+	if !found {
+		t.Errorf("error chain does not contain error of type '%T':\n%s", result, FormatChain(err))
+	}
+	return result, found
+}
+
+// ContainsPosition asserts that an error of the chain was created in the function.
+// Function is the package-qualified function name ("github.com/me/project/user.Load"),
+// or its suffix after a "." or a "/" separator ("user.Load", "Load").
+func ContainsPosition(t testing.TB, err error, function string) bool {
+	t.Helper()
+	if errors.Walk(err, func(member error) bool {
+		positioned, ok := member.(interface{ Function() string })
+		return ok && matchFunction(positioned.Function(), function)
+	}) {
+		return true
+	}
+	t.Errorf("error chain does not contain position in function '%s':\n%s", function, FormatChain(err))
+	return false
+}
+
+// ChainLength asserts that the chain contains the number of errors (aggregated errors included).
+func ChainLength(t testing.TB, err error, length int) bool {
+	t.Helper()
+	count := 0
+	errors.Walk(err, func(error) bool {
+		count++
+		return false
+	})
+	if count == length {
+		return true
+	}
+	t.Errorf("error chain length is %d, want %d:\n%s", count, length, FormatChain(err))
+	return false
+}
+
+// FormatChain returns the description of every error of the chain, one error by line,
+// with type, message and position. Aggregated errors are indented.
+func FormatChain(err error) string {
+	if err == nil {
+		return "    <nil>"
+	}
+	lines := make([]string, 0)
+	formatChain(err, 1, &lines)
+	return strings.Join(lines, "\n")
+}
+
+// formatChain appends the description of the error and its causes to lines.
+func formatChain(err error, depth int, lines *[]string) {
+	for ; err != nil; err = goerr.Unwrap(err) {
+		line := fmt.Sprintf("%s%T: %s", strings.Repeat("    ", depth), err, message(err))
+		if traceable, ok := err.(interface {
+			File() string
+			Function() string
+			Line() int
+		}); ok && traceable.Line() > 0 {
+			line += " ( at " + errors.FormatPosition(traceable.File(), traceable.Function(), traceable.Line(), errors.PositionFunction) + " )"
+		}
+		*lines = append(*lines, line)
+		if multiple, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, member := range multiple.Unwrap() {
+				formatChain(member, depth+1, lines)
+			}
+			return
+		}
+	}
+}
+
+// message returns the message of the error, without causes if error has a `Message() string` method.
+func message(err error) string {
+	if messager, ok := err.(interface{ Message() string }); ok {
+		return messager.Message()
+	}
+	return err.Error()
+}
+
+// matchFunction returns true if name is the function, or if name ends with "." or "/" followed by function.
+func matchFunction(name string, function string) bool {
+	return name == function || strings.HasSuffix(name, "."+function) || strings.HasSuffix(name, "/"+function)
+}
@@ -0,0 +1,68 @@
+package errorstest
+
+import (
+	"fmt"
+	"github.com/deverdeb/bvmgo-util/errors"
+	"strings"
+	"testing"
+)
+
+// recorder is a testing.TB which records failures.
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+// loadUser returns an error chain for assertions tests.
+func loadUser() error {
+	cause := fmt.Errorf("connection refused")
+	notFound := errors.WithCode(errors.NewWithCause(cause, "user %s not found", "bob"), "USR-404")
+	return errors.Append(fmt.Errorf("first failure"), errors.Wrap(notFound))
+}
+
+func TestAssertions(t *testing.T) {
+	err := loadUser()
+	tests := []struct {
+		name   string
+		assert func(t testing.TB) bool
+		want   bool
+	}{
+		{name: "message found", assert: func(t testing.TB) bool { return ContainsMessage(t, err, "bob not found") }, want: true},
+		{name: "message not found", assert: func(t testing.TB) bool { return ContainsMessage(t, err, "alice") }, want: false},
+		{name: "code found", assert: func(t testing.TB) bool { return ContainsCode(t, err, "USR-404") }, want: true},
+		{name: "code not found", assert: func(t testing.TB) bool { return ContainsCode(t, err, "USR-409") }, want: false},
+		{name: "position found", assert: func(t testing.TB) bool { return ContainsPosition(t, err, "errorstest.loadUser") }, want: true},
+		{name: "position not found", assert: func(t testing.TB) bool { return ContainsPosition(t, err, "TestAssertions") }, want: false},
+		{name: "chain length", assert: func(t testing.TB) bool { return ChainLength(t, err, 5) }, want: true},
+		{name: "wrong chain length", assert: func(t testing.TB) bool { return ChainLength(t, err, 2) }, want: false},
+		{name: "type found", assert: func(t testing.TB) bool {
+			multiError, ok := ContainsType[*errors.MultiError](t, err)
+			return ok && len(multiError.Errors()) == 2
+		}, want: true},
+		{name: "type not found", assert: func(t testing.TB) bool {
+			_, ok := ContainsType[*errors.FieldError](t, err)
+			return ok
+		}, want: false},
+		{name: "cause not found", assert: func(t testing.TB) bool { return ContainsCause(t, err, errors.Code("USR-409")) }, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &recorder{TB: t}
+			if got := tt.assert(r); got != tt.want {
+				t.Errorf("assertion = %v, want %v", got, tt.want)
+			}
+			if tt.want != (len(r.failures) == 0) {
+				t.Errorf("failures = %v, want %v failure", r.failures, !tt.want)
+			}
+			if len(r.failures) > 0 && !strings.Contains(r.failures[0], "*errors.customError: user bob not found ( at ") {
+				t.Errorf("failure '%s' does not contain formatted chain", r.failures[0])
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+// Package exit ends command line tools with an error: exit code, user summary and logs flush.
+package exit
+
+import (
+	goerr "errors"
+	"fmt"
+	"github.com/deverdeb/bvmgo-util/errors"
+	"github.com/deverdeb/bvmgo-util/logs"
+	"github.com/deverdeb/bvmgo-util/term"
+	"io"
+	"os"
+	"strconv"
+)
+
+// VerboseVariable is the environment variable which enables the verbose mode of new handlers (example: "ERRORS_VERBOSE=true").
+const VerboseVariable = "ERRORS_VERBOSE"
+
+const (
+	// CodeSuccess is the exit code without error.
+	CodeSuccess = 0
+	// CodeFailure is the exit code of errors without exit code nor mapped kind.
+	CodeFailure = 1
+)
+
+// exit function exits application with the given status code.
+var exit = os.Exit
+
+// Coder is an error with a process exit code.
+type Coder interface {
+	// ExitCode returns the process exit code.
+	ExitCode() int
+}
+
+// Handler ends the process with an error.
+// Exit code is the code of the first Coder error found in the chain, then the code of the error kind.
+// Errors without exit code nor mapped kind exit with CodeFailure.
+type Handler struct {
+	// Verbose is true if the detailed error chain (positions, causes, stack traces) is written.
+	// Otherwise, the one line error message is written.
+	Verbose bool
+	// Output is the destination of the error summary (os.Stderr by default).
+	Output io.Writer
+	// codeByKind contains the exit code of error kinds.
+	codeByKind map[errors.Kind]int
+}
+
+// NewHandler creates a handler writing to os.Stderr, with the default kinds mapping (sysexits.h codes):
+// errors.KindInvalidInput (65), errors.KindNotFound (66) and errors.KindUnavailable (69).
+// Verbose mode is enabled if VerboseVariable environment variable is true.
+func NewHandler() *Handler {
+	verbose, _ := strconv.ParseBool(os.Getenv(VerboseVariable))
+	return &Handler{
+		Verbose: verbose,
+		Output:  os.Stderr,
+		codeByKind: map[errors.Kind]int{
+			errors.KindInvalidInput: 65,
+			errors.KindNotFound:     66,
+			errors.KindUnavailable:  69,
+		},
+	}
+}
+
+// SetKindCode sets the exit code of errors of the kind.
+func (handler *Handler) SetKindCode(kind errors.Kind, code int) {
+	handler.codeByKind[kind] = code
+}
+
+// Code returns the exit code of the error. CodeSuccess is returned if err is nil.
+// Every error of the chain is checked, aggregated errors included.
+func (handler *Handler) Code(err error) int {
+	if err == nil {
+		return CodeSuccess
+	}
+	var coder Coder
+	if goerr.As(err, &coder) {
+		return coder.ExitCode()
+	}
+	code, found := CodeFailure, false
+	errors.Walk(err, func(member error) bool {
+		if kinded, ok := member.(errors.Kinder); ok {
+			code, found = handler.codeByKind[kinded.Kind()]
+		}
+		return found
+	})
+	if !found {
+		return CodeFailure
+	}
+	return code
+}
+
+// Print writes the error summary: a one line message, or the detailed error chain in verbose mode.
+// Label is colored if Output is a terminal and colors are enabled (see term.ColorEnabled).
+// Nothing is written if err is nil.
+func (handler *Handler) Print(err error) {
+	if err == nil {
+		return
+	}
+	label := "error:"
+	if term.ColorEnabled() && term.IsTerminal(handler.Output) {
+		label = term.LightRed.Sprint(label)
+	}
+	if handler.Verbose {
+		_, _ = fmt.Fprintf(handler.Output, "%s %+v\n", label, err)
+	} else {
+		_, _ = fmt.Fprintf(handler.Output, "%s %v\n", label, err)
+	}
+}
+
+// Exit does nothing if err is nil. Otherwise, it writes the error summary,
+// flushes the default logger and exits with the error exit code.
+func (handler *Handler) Exit(err error) {
+	if err == nil {
+		return
+	}
+	handler.Print(err)
+	_ = logs.Flush()
+	exit(handler.Code(err))
+}
+
+// OnError ends the process with a new handler if err is not nil (see Handler.Exit).
+//
+//	func main() {
+//		exit.OnError(run())
+//	}
+func OnError(err error) {
+	NewHandler().Exit(err)
+}
@@ -0,0 +1,113 @@
+package exit
+
+import (
+	"fmt"
+	"github.com/deverdeb/bvmgo-util/errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+// exitCodeError is an error with an exit code.
+type exitCodeError struct {
+	code int
+}
+
+func (err *exitCodeError) Error() string {
+	return "exit code error"
+}
+
+func (err *exitCodeError) ExitCode() int {
+	return err.code
+}
+
+func TestHandler_Code(t *testing.T) {
+	handler := NewHandler()
+	handler.SetKindCode(errors.KindConflict, 75)
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "nil error", err: nil, want: CodeSuccess},
+		{name: "unknown error", err: fmt.Errorf("unknown error"), want: CodeFailure},
+		{name: "invalid input kind", err: errors.WithKind(errors.New("invalid"), errors.KindInvalidInput), want: 65},
+		{name: "wrapped not found kind", err: errors.Wrap(errors.WithKind(errors.New("missing"), errors.KindNotFound)), want: 66},
+		{name: "configured kind", err: errors.WithKind(errors.New("conflict"), errors.KindConflict), want: 75},
+		{name: "exit code in chain", err: errors.NewWithCause(&exitCodeError{code: 3}, "failed"), want: 3},
+		{name: "exit code in aggregated errors", err: errors.Append(fmt.Errorf("first"), &exitCodeError{code: 4}), want: 4},
+		{name: "kind in aggregated errors", err: errors.Append(fmt.Errorf("first"), errors.WithKind(errors.New("down"), errors.KindUnavailable)), want: 69},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := handler.Code(tt.err); got != tt.want {
+				t.Errorf("Code() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandler_Exit(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	var exitCode *int
+	exit = func(code int) {
+		exitCode = &code
+	}
+	defer func() {
+		exit = os.Exit
+	}()
+	cause := errors.New("cause error")
+	err := errors.NewWithCause(cause, "command failed")
+	tests := []struct {
+		name      string
+		err       error
+		verbose   bool
+		wantCode  *int
+		wantLines int
+	}{
+		{name: "nil error", err: nil, wantCode: nil, wantLines: 0},
+		{name: "summary", err: err, verbose: false, wantCode: intPointer(CodeFailure), wantLines: 1},
+		{name: "verbose", err: err, verbose: true, wantCode: intPointer(CodeFailure), wantLines: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exitCode = nil
+			output := &strings.Builder{}
+			handler := NewHandler()
+			handler.Verbose = tt.verbose
+			handler.Output = output
+			handler.Exit(tt.err)
+			if (exitCode == nil) != (tt.wantCode == nil) || (exitCode != nil && *exitCode != *tt.wantCode) {
+				t.Errorf("Exit() exit code = %v, want %v", exitCode, tt.wantCode)
+			}
+			if lines := strings.Count(output.String(), "\n"); lines != tt.wantLines {
+				t.Errorf("Exit() output = '%s', want %d line(s)", output.String(), tt.wantLines)
+			}
+			if tt.err != nil && !strings.HasPrefix(output.String(), "error: command failed") {
+				t.Errorf("Exit() output = '%s', want prefix 'error: command failed'", output.String())
+			}
+		})
+	}
+}
+
+func intPointer(value int) *int {
+	return &value
+}
+
+func TestHandler_Print_notTerminal(t *testing.T) {
+	t.Setenv("TERM", "xterm")
+	t.Setenv("NO_COLOR", "")
+	_ = os.Unsetenv("NO_COLOR") // Restored by t.Setenv.
+	output, err := os.CreateTemp(t.TempDir(), "output")
+	if err != nil {
+		t.Fatalf("CreateTemp() error = %v", err)
+	}
+	defer output.Close()
+	handler := NewHandler()
+	handler.Output = output
+	handler.Print(errors.New("command failed"))
+	content, _ := os.ReadFile(output.Name())
+	if !strings.HasPrefix(string(content), "error: command failed") {
+		t.Errorf("Print() output = '%s', want label without color", content)
+	}
+}
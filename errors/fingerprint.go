@@ -0,0 +1,88 @@
+package errors
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	goerr "errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// Fingerprint returns a stable identifier of the error chain, to group identical failures.
+//
+// Fingerprint is computed from codes, creation positions (function and line) and message formats of the chain,
+// but not from the message arguments: errors built at the same position with different arguments
+// (example: identifiers) have the same fingerprint. Foreign errors are identified by their type,
+// and wrapped foreign errors by their wrapping position. Sentinel foreign errors (comparable errors without cause,
+// example: io.EOF) are identified by their message too, without digits (dynamic messages often contain identifiers).
+// Field errors are identified by their field path too.
+// Function returns an empty string for a nil error.
+func Fingerprint(err error) string {
+	if err == nil {
+		return ""
+	}
+	hash := sha256.New()
+	writeFingerprint(hash, err)
+	return hex.EncodeToString(hash.Sum(nil)[:8])
+}
+
+// writeFingerprint writes the fingerprint data of error chain.
+func writeFingerprint(output io.Writer, err error) {
+	for ; err != nil; err = goerr.Unwrap(err) {
+		switch typedErr := err.(type) {
+		case *customError:
+			writeCustomErrorFingerprint(output, typedErr)
+			_, _ = fmt.Fprintln(output)
+		case *FieldError:
+			writeCustomErrorFingerprint(output, typedErr.customError)
+			_, _ = fmt.Fprintf(output, "|%s\n", typedErr.path)
+		case interface{ Unwrap() []error }:
+			_, _ = fmt.Fprintf(output, "%T", err)
+			switch positionErr := err.(type) {
+			case *MultiError:
+				_, _ = fmt.Fprintf(output, "|%s|%d", positionErr.function, positionErr.line)
+			case *ValidationError:
+				_, _ = fmt.Fprintf(output, "|%s|%d", positionErr.function, positionErr.line)
+			}
+			_, _ = fmt.Fprintln(output, "|[")
+			for _, member := range typedErr.Unwrap() {
+				writeFingerprint(output, member)
+			}
+			_, _ = fmt.Fprintln(output, "]")
+			return
+		default:
+			if isSentinelError(err) {
+				_, _ = fmt.Fprintf(output, "%T|%s\n", err, strings.Map(removeDigit, err.Error()))
+			} else {
+				_, _ = fmt.Fprintf(output, "%T\n", err)
+			}
+		}
+	}
+}
+
+// writeCustomErrorFingerprint writes the code, the position and the message format of the traceable error,
+// without line feed. The message is used if error has no message format (example: error read from JSON).
+func writeCustomErrorFingerprint(output io.Writer, err *customError) {
+	template := err.format
+	if template == "" {
+		template = err.message
+	}
+	_, _ = fmt.Fprintf(output, "%s|%s|%d|%s", err.code, err.function, err.line, template)
+}
+
+// isSentinelError checks if the foreign error is a sentinel error: a comparable error without cause
+// (example: io.EOF), compared with `==` or errors.Is function.
+func isSentinelError(err error) bool {
+	return reflect.TypeOf(err).Comparable() && goerr.Unwrap(err) == nil
+}
+
+// removeDigit is a strings.Map function which removes the digits.
+func removeDigit(char rune) rune {
+	if unicode.IsDigit(char) {
+		return -1
+	}
+	return char
+}
@@ -0,0 +1,98 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"testing"
+)
+
+// loadUserFailure builds the same error at the same position for all users.
+func loadUserFailure(userId int, cause error) error {
+	return NewWithCause(cause, "failed to load user %d", userId)
+}
+
+func TestFingerprint(t *testing.T) {
+	reference := Fingerprint(loadUserFailure(1, fmt.Errorf("user 1 not found")))
+	tests := []struct {
+		name     string
+		err      error
+		wantSame bool
+	}{
+		{
+			name:     "other arguments",
+			err:      loadUserFailure(2, fmt.Errorf("user 2 not found")),
+			wantSame: true,
+		},
+		{
+			name:     "other position",
+			err:      NewWithCause(fmt.Errorf("user 1 not found"), "failed to load user %d", 1),
+			wantSame: false,
+		},
+		{
+			name:     "other code",
+			err:      WithCode(loadUserFailure(1, fmt.Errorf("user 1 not found")), "USR-404"),
+			wantSame: false,
+		},
+		{
+			name:     "other cause type",
+			err:      loadUserFailure(1, New("user 1 not found")),
+			wantSame: false,
+		},
+		{
+			name:     "aggregated errors",
+			err:      Append(nil, loadUserFailure(1, fmt.Errorf("user 1 not found"))),
+			wantSame: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Fingerprint(tt.err); (got == reference) != tt.wantSame || len(got) != 16 {
+				t.Errorf("Fingerprint() = '%v', reference '%v', want same = %v", got, reference, tt.wantSame)
+			}
+		})
+	}
+	firstWrapped := Wrap(fmt.Errorf("user 1 not found"))
+	if Fingerprint(firstWrapped) == Fingerprint(Wrap(fmt.Errorf("user 1 not found"))) {
+		t.Errorf("Fingerprint() of wrapped errors at different positions are equal")
+	}
+	wrapped := []error{Wrap(fmt.Errorf("user 1 not found")), Wrap(fmt.Errorf("user 2 not found"))}
+	if Fingerprint(wrapped[0]) != Fingerprint(wrapped[1]) {
+		t.Errorf("Fingerprint() of wrapped errors = '%v' and '%v', want same", Fingerprint(wrapped[0]), Fingerprint(wrapped[1]))
+	}
+	sentinels := []error{Wrap(io.EOF), Wrap(io.ErrUnexpectedEOF)}
+	if Fingerprint(sentinels[0]) == Fingerprint(sentinels[1]) {
+		t.Errorf("Fingerprint() of wrapped sentinel errors are equal")
+	}
+	if Fingerprint(nil) != "" {
+		t.Errorf("Fingerprint(nil) = '%v', want empty", Fingerprint(nil))
+	}
+}
+
+// validateName returns the validation error of an empty name.
+func validateName() error {
+	validation := NewValidationError()
+	validation.Add("name", "REQUIRED", "name is required")
+	return validation
+}
+
+func TestFingerprint_validation(t *testing.T) {
+	reference := validateName()
+	if Fingerprint(validateName()) != Fingerprint(reference) {
+		t.Errorf("Fingerprint() of same validation = '%v', want '%v'", Fingerprint(validateName()), Fingerprint(reference))
+	}
+	otherPosition := NewValidationError()
+	otherPosition.Add("name", "REQUIRED", "name is required")
+	if Fingerprint(otherPosition) == Fingerprint(reference) {
+		t.Errorf("Fingerprint() of validation at other position = '%v', want different", Fingerprint(otherPosition))
+	}
+	otherPath := NewValidationError()
+	otherPath.AddError("address.name", reference.(*ValidationError).Errors()[0].customError)
+	samePosition := NewValidationError()
+	samePosition.AddError("name", reference.(*ValidationError).Errors()[0].customError)
+	if Fingerprint(otherPath) == Fingerprint(samePosition) {
+		t.Errorf("Fingerprint() of field errors with other paths are equal")
+	}
+	if Fingerprint(reference.(*ValidationError).Errors()[0]) == Fingerprint(reference.(*ValidationError).Errors()[0].customError) {
+		t.Errorf("Fingerprint() of field error ignores its path")
+	}
+}
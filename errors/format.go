@@ -0,0 +1,108 @@
+package errors
+
+import (
+	goerr "errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Format implements fmt.Formatter interface:
+//   - `%s` and `%v` print the error message and the causes messages on one line.
+//   - `%+v` prints the detailed causes chain, with positions and stack traces.
+//   - `%q` prints the quoted `%s` message.
+func (err *customError) Format(state fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if state.Flag('+') {
+			_, _ = io.WriteString(state, err.detailedMessage())
+			return
+		}
+		_, _ = io.WriteString(state, err.oneLineMessage(""))
+	case 's':
+		_, _ = io.WriteString(state, err.oneLineMessage(""))
+	case 'q':
+		_, _ = fmt.Fprintf(state, "%q", err.oneLineMessage(""))
+	default:
+		_, _ = fmt.Fprintf(state, "%%!%c(%s)", verb, err.oneLineMessage(""))
+	}
+}
+
+// oneLineMessage returns the error message followed by causes messages, separated by ": ".
+// Causes messages equal to the previous message are ignored (see Wrap function).
+// Aggregated errors of a cause (`Unwrap() []error` method) are listed between brackets, separated by "; ".
+// Messages are localized if locale is not empty (see Localize function).
+func (err *customError) oneLineMessage(locale string) string {
+	messages := []string{err.localizedMessage(locale)}
+	appendMessage := func(message string) {
+		if message != messages[len(messages)-1] {
+			messages = append(messages, message)
+		}
+	}
+	cause := err.cause
+	for cause != nil {
+		if customCause, ok := cause.(*customError); ok {
+			appendMessage(customCause.localizedMessage(locale))
+		} else if _, ok := cause.(interface{ Unwrap() []error }); ok {
+			appendMessage(oneLineMessageOf(cause, locale))
+			break
+		} else if messageCause, ok := cause.(interface{ Message() string }); ok {
+			appendMessage(messageCause.Message())
+		} else {
+			// Error() contains the remaining causes.
+			appendMessage(oneLineMessageOf(cause, locale))
+			break
+		}
+		wrapper, ok := cause.(interface{ Unwrap() error })
+		if !ok {
+			break
+		}
+		cause = wrapper.Unwrap()
+	}
+	return strings.Join(messages, ": ")
+}
+
+// oneLineMessageOf returns the one line message of the error and its causes.
+// Aggregated errors (`Unwrap() []error` method) are listed between brackets after the error message.
+// If locale is not empty, the cause message of foreign wrappers (example: fmt.Errorf with %w verb) is localized.
+func oneLineMessageOf(err error, locale string) string {
+	switch typedErr := err.(type) {
+	case *customError:
+		return typedErr.oneLineMessage(locale)
+	case *FieldError:
+		return typedErr.path + ": " + typedErr.oneLineMessage(locale)
+	case interface{ Unwrap() []error }:
+		members := make([]string, 0)
+		for _, member := range typedErr.Unwrap() {
+			if member != nil {
+				members = append(members, oneLineMessageOf(member, locale))
+			}
+		}
+		message := "[" + strings.Join(members, "; ") + "]"
+		if messageErr, ok := err.(interface{ Message() string }); ok {
+			message = messageErr.Message() + ": " + message
+		}
+		return message
+	default:
+		message := err.Error()
+		if cause := goerr.Unwrap(err); locale != "" && cause != nil {
+			// Foreign wrapper message ends with the cause message (`%w` verb formats as `%v`): it is localized.
+			if prefix, found := strings.CutSuffix(message, fmt.Sprintf("%v", cause)); found {
+				return strings.TrimSpace(strings.Join(strings.Fields(prefix), " ") + " " + oneLineMessageOf(cause, locale))
+			}
+		}
+		return strings.Join(strings.Fields(message), " ")
+	}
+}
+
+// detailedMessage returns the error message with position and stack trace, followed by detailed causes.
+func (err *customError) detailedMessage() string {
+	message := err.Message() + err.position()
+	for _, frame := range err.stack.Frames() {
+		message += "\n        at " + frame.String()
+	}
+	if err.cause != nil {
+		message += "\n    > cause by: " + fmt.Sprintf("%+v", err.cause)
+	}
+	return message
+}
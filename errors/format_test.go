@@ -0,0 +1,71 @@
+package errors
+
+import (
+	goerr "errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestErrorFormat(t *testing.T) {
+	cause := NewWithCause(fmt.Errorf("root\n  error"), "cause error")
+	err := NewWithCause(Wrap(cause), "main error")
+	tests := []struct {
+		name   string
+		format string
+		want   string
+	}{
+		{
+			name:   "string verb",
+			format: "%s",
+			want:   "main error: cause error: root error",
+		},
+		{
+			name:   "value verb",
+			format: "%v",
+			want:   "main error: cause error: root error",
+		},
+		{
+			name:   "quoted verb",
+			format: "%q",
+			want:   `"main error: cause error: root error"`,
+		},
+		{
+			name:   "unsupported verb",
+			format: "%d",
+			want:   "%!d(main error: cause error: root error)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fmt.Sprintf(tt.format, err); got != tt.want {
+				t.Errorf("Sprintf(%s) = '%v', want '%v'", tt.format, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorFormat_multipleCauses(t *testing.T) {
+	validation := NewValidationError()
+	validation.Add("name", "", "is required")
+	multiError := Append(New("first error"), NewWithCause(goerr.Join(goerr.New("a"), goerr.New("b")), "second error"), validation)
+	err := NewWithCause(multiError, "main error")
+	want := "main error: 3 error(s) occurred: [first error; second error: [a; b]; invalid input, 1 field error(s) found: [name: is required]]"
+	if got := fmt.Sprintf("%v", err); got != want {
+		t.Errorf("Sprintf(%%v) = '%v', want '%v'", got, want)
+	}
+}
+
+func TestErrorFormat_detailed(t *testing.T) {
+	err := NewWithCause(WithStack(New("cause error")), "main error")
+	got := fmt.Sprintf("%+v", err)
+	for _, want := range []string{
+		"main error ( at ",
+		"\n    > cause by: cause error ( at ",
+		"\n        at github.com/deverdeb/bvmgo-util/errors.TestErrorFormat_detailed ( ",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Sprintf(%%+v) = '%v', want contains '%v'", got, want)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// JSON types of errors (see jsonError.Type). Traceable errors have no type.
+const (
+	// jsonTypeMulti is the JSON type of MultiError.
+	jsonTypeMulti = "multi"
+	// jsonTypeValidation is the JSON type of ValidationError.
+	jsonTypeValidation = "validation"
+	// jsonTypeField is the JSON type of FieldError.
+	jsonTypeField = "field"
+)
+
+// jsonError is the JSON representation of an error chain.
+type jsonError struct {
+	// Type is the error type discriminator: "multi", "validation", "field", or empty for other errors.
+	Type string `json:"type,omitempty"`
+	// Message is the error message (or the Error() result of foreign errors).
+	Message string `json:"message"`
+	// File is the filename.
+	File string `json:"file,omitempty"`
+	// Function is the name of function in file.
+	Function string `json:"function,omitempty"`
+	// Line is the line in file.
+	Line int `json:"line,omitempty"`
+	// Code is the machine-readable error code.
+	Code Code `json:"code,omitempty"`
+	// Kind is the error category.
+	Kind Kind `json:"kind,omitempty"`
+	// Public is the user-safe message (see WithPublicMessage).
+	Public string `json:"public,omitempty"`
+	// Field is the field path of a validation field error.
+	Field string `json:"field,omitempty"`
+	// Attributes contains the error attributes, without causes attributes.
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	// Sensitive contains the keys of sensitive attributes (see WithSensitive), sorted.
+	Sensitive []string `json:"sensitive,omitempty"`
+	// Cause is the cause error.
+	Cause *jsonError `json:"cause,omitempty"`
+	// Errors contains the aggregated errors of a MultiError or the field errors of a ValidationError.
+	Errors []*jsonError `json:"errors,omitempty"`
+}
+
+// ToJSON converts any error to JSON.
+// Traceable errors and MultiError are written with their causes. Foreign errors are written with their message only.
+// A nil error is written as `null`.
+func ToJSON(err error) ([]byte, error) {
+	return json.Marshal(toJSONError(err))
+}
+
+// FromJSON rebuilds an error chain from JSON (see ToJSON function), and stores it in the value pointed to by target.
+// Errors are rebuilt as traceable errors, or as MultiError, ValidationError and FieldError according to their JSON type.
+// Attributes values are decoded as JSON values (float64 for numbers, map[string]interface{} for objects...).
+// The returned error is the decoding error: target is not modified if JSON is invalid.
+//
+//	var err error
+//	if decodeErr := errors.FromJSON(data, &err); decodeErr != nil {
+//		return decodeErr
+//	}
+func FromJSON(data []byte, target *error) error {
+	var decoded *jsonError
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return NewWithCause(err, "failed to decode JSON error")
+	}
+	*target = fromJSONError(decoded)
+	return nil
+}
+
+// MarshalJSON method converts the error chain to JSON. json.Marshaler interface method.
+func (err *customError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(toJSONError(err))
+}
+
+// MarshalJSON method converts the aggregated errors to JSON. json.Marshaler interface method.
+func (err *MultiError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(toJSONError(err))
+}
+
+// MarshalJSON method converts the field error to JSON. json.Marshaler interface method.
+func (err *FieldError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(toJSONError(err))
+}
+
+// MarshalJSON method converts the validation error and its field errors to JSON. json.Marshaler interface method.
+func (err *ValidationError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(toJSONError(err))
+}
+
+// toJSONError converts the error to its JSON representation.
+func toJSONError(err error) *jsonError {
+	switch typedErr := err.(type) {
+	case nil:
+		return nil
+	case *customError:
+		result := &jsonError{
+			Message:  typedErr.Message(),
+			File:     typedErr.file,
+			Function: typedErr.function,
+			Line:     typedErr.line,
+			Code:     typedErr.code,
+			Kind:     typedErr.kind,
+			Public:   typedErr.public,
+			Cause:    toJSONError(typedErr.cause),
+		}
+		if len(typedErr.attributes) > 0 {
+			result.Attributes = make(map[string]interface{}, len(typedErr.attributes))
+			for _, attribute := range typedErr.attributes {
+				result.Attributes[attribute.Key] = toJSONValue(attribute.Value)
+				if attribute.Sensitive {
+					result.Sensitive = append(result.Sensitive, attribute.Key)
+				}
+			}
+			sort.Strings(result.Sensitive)
+		}
+		return result
+	case *FieldError:
+		result := toJSONError(typedErr.customError)
+		result.Type = jsonTypeField
+		result.Field = typedErr.path
+		return result
+	case *ValidationError:
+		result := &jsonError{
+			Type:     jsonTypeValidation,
+			Message:  typedErr.Message(),
+			File:     typedErr.file,
+			Function: typedErr.function,
+			Line:     typedErr.line,
+			Kind:     typedErr.Kind(),
+			Errors:   make([]*jsonError, 0, len(typedErr.errors)),
+		}
+		for _, fieldError := range typedErr.errors {
+			result.Errors = append(result.Errors, toJSONError(fieldError))
+		}
+		return result
+	case *MultiError:
+		result := &jsonError{
+			Type:     jsonTypeMulti,
+			Message:  typedErr.Message(),
+			File:     typedErr.file,
+			Function: typedErr.function,
+			Line:     typedErr.line,
+			Errors:   make([]*jsonError, 0, len(typedErr.errors)),
+		}
+		for _, member := range typedErr.errors {
+			result.Errors = append(result.Errors, toJSONError(member))
+		}
+		return result
+	default:
+		return &jsonError{Message: err.Error()}
+	}
+}
+
+// toJSONValue returns the attribute value if it can be converted to JSON, its `fmt.Sprint` string otherwise.
+func toJSONValue(value interface{}) interface{} {
+	if _, err := json.Marshal(value); err != nil {
+		return fmt.Sprint(value)
+	}
+	return value
+}
+
+// fromJSONError rebuilds the error from its JSON representation.
+func fromJSONError(decoded *jsonError) error {
+	if decoded == nil {
+		return nil
+	}
+	if decoded.Type == jsonTypeValidation {
+		result := &ValidationError{
+			errors:   make([]*FieldError, 0, len(decoded.Errors)),
+			file:     decoded.File,
+			function: decoded.Function,
+			line:     decoded.Line,
+		}
+		for _, member := range decoded.Errors {
+			if member == nil {
+				continue
+			}
+			fieldError, ok := fromJSONError(member).(*FieldError)
+			if !ok {
+				fieldError = &FieldError{customError: fromJSONCustomError(member), path: member.Field}
+			}
+			result.errors = append(result.errors, fieldError)
+		}
+		return result
+	}
+	if decoded.Type == jsonTypeMulti || decoded.Errors != nil {
+		result := &MultiError{
+			errors:   make([]error, 0, len(decoded.Errors)),
+			file:     decoded.File,
+			function: decoded.Function,
+			line:     decoded.Line,
+		}
+		result.errors = appendNotNilErrors(result.errors, fromJSONErrors(decoded.Errors))
+		return result
+	}
+	result := fromJSONCustomError(decoded)
+	if decoded.Type == jsonTypeField || decoded.Field != "" {
+		return &FieldError{customError: result, path: decoded.Field}
+	}
+	return result
+}
+
+// fromJSONCustomError rebuilds the traceable error from its JSON representation, without its type.
+func fromJSONCustomError(decoded *jsonError) *customError {
+	result := &customError{
+		message:  decoded.Message,
+		file:     decoded.File,
+		function: decoded.Function,
+		line:     decoded.Line,
+		code:     decoded.Code,
+		kind:     decoded.Kind,
+		public:   decoded.Public,
+		cause:    fromJSONError(decoded.Cause),
+	}
+	// Attributes are sorted by key: JSON objects are not ordered.
+	keys := make([]string, 0, len(decoded.Attributes))
+	for key := range decoded.Attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	sensitive := make(map[string]bool, len(decoded.Sensitive))
+	for _, key := range decoded.Sensitive {
+		sensitive[key] = true
+	}
+	for _, key := range keys {
+		result.attributes = append(result.attributes, Attribute{Key: key, Value: decoded.Attributes[key], Sensitive: sensitive[key]})
+	}
+	return result
+}
+
+// fromJSONErrors rebuilds the errors from their JSON representations.
+func fromJSONErrors(decoded []*jsonError) []error {
+	result := make([]error, 0, len(decoded))
+	for _, member := range decoded {
+		result = append(result, fromJSONError(member))
+	}
+	return result
+}
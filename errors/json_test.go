@@ -0,0 +1,110 @@
+package errors
+
+import (
+	"encoding/json"
+	goerr "errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestToJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "nil error",
+			err:  nil,
+			want: `null`,
+		},
+		{
+			name: "foreign error",
+			err:  fmt.Errorf("foreign error"),
+			want: `{"message":"foreign error"}`,
+		},
+		{
+			name: "traceable error",
+			err: With(WithKind(WithCode(buildCustomError(fmt.Errorf("root error"), "user.go", "pkg.Load", 12, "user not found"),
+				codeUserNotFound), KindNotFound), "userId", 42),
+			want: `{"message":"user not found","file":"user.go","function":"pkg.Load","line":12,"code":"USER_NOT_FOUND",` +
+				`"kind":"not_found","attributes":{"userId":42},"cause":{"message":"root error"}}`,
+		},
+		{
+			name: "aggregated errors",
+			err:  &MultiError{errors: []error{fmt.Errorf("first error"), fmt.Errorf("second error")}},
+			want: `{"type":"multi","message":"2 error(s) occurred","errors":[{"message":"first error"},{"message":"second error"}]}`,
+		},
+		{
+			name: "sensitive attribute",
+			err:  WithSensitive(buildCustomError(nil, "", "", 0, "authentication failed"), "token", "abc123"),
+			want: `{"message":"authentication failed","attributes":{"token":"abc123"},"sensitive":["token"]}`,
+		},
+		{
+			name: "attribute without JSON representation",
+			err:  With(buildCustomError(nil, "", "", 0, "invalid impedance"), "impedance", complex(50, 10)),
+			want: `{"message":"invalid impedance","attributes":{"impedance":"(50+10i)"}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := ToJSON(tt.err)
+			if err != nil {
+				t.Fatalf("ToJSON() error = %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("ToJSON() = '%s', want '%s'", data, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromJSON(t *testing.T) {
+	original := NewWithCause(Append(nil, fmt.Errorf("first error"), With(New("second error"), "userId", "john")),
+		"validation failed")
+	original = WithKind(WithCode(original, codeUserNotFound), KindInvalidInput)
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var decoded error
+	if err := FromJSON(data, &decoded); err != nil {
+		t.Fatalf("FromJSON() error = %v", err)
+	}
+	if decoded.Error() != original.Error() {
+		t.Errorf("FromJSON() = '%v', want '%v'", decoded.Error(), original.Error())
+	}
+	if !goerr.Is(decoded, codeUserNotFound) || KindOf(decoded) != KindInvalidInput {
+		t.Errorf("FromJSON() code = '%v', kind = '%v'", CodeOf(decoded), KindOf(decoded))
+	}
+	traceable := decoded.(TraceableError)
+	if traceable.Line() != original.(TraceableError).Line() || traceable.Function() != original.(TraceableError).Function() {
+		t.Errorf("FromJSON() position = '%v:%v'", traceable.Function(), traceable.Line())
+	}
+	var member Attributer
+	multiError := traceable.Cause().(*MultiError)
+	if len(multiError.Errors()) != 2 || !goerr.As(multiError.Errors()[1], &member) ||
+		!reflect.DeepEqual(member.Attributes(), []Attribute{{Key: "userId", Value: "john"}}) {
+		t.Errorf("FromJSON() members = '%v'", multiError.Errors())
+	}
+	if err := FromJSON([]byte("{"), &decoded); err == nil {
+		t.Errorf("FromJSON() error = nil, want error")
+	}
+}
+
+func TestFromJSON_sensitiveAttributes(t *testing.T) {
+	original := WithSensitive(With(New("authentication failed"), "user", "bob"), "token", "abc123")
+	data, err := ToJSON(original)
+	if err != nil {
+		t.Fatalf("ToJSON() error = %v", err)
+	}
+	var decoded error
+	if err := FromJSON(data, &decoded); err != nil {
+		t.Fatalf("FromJSON() error = %v", err)
+	}
+	want := []Attribute{{Key: "token", Value: RedactedValue, Sensitive: true}, {Key: "user", Value: "bob"}}
+	if got := SafeAttributes(decoded); !reflect.DeepEqual(got, want) {
+		t.Errorf("SafeAttributes() = '%v', want '%v'", got, want)
+	}
+}
@@ -0,0 +1,74 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// messageCatalogs contains the localized message formats, by locale and by message key.
+var messageCatalogs = struct {
+	mutex    sync.RWMutex
+	catalogs map[string]map[string]string
+}{catalogs: make(map[string]map[string]string)}
+
+// AddMessages adds localized message formats to the catalog of the locale (examples: "fr", "fr-CA", "en").
+//
+// Messages are indexed by message key: the format of errors built with New or NewWithCause functions,
+// or the code of errors built from a Definition.
+//
+//	errors.AddMessages("fr", map[string]string{
+//		"user %s not found": "utilisateur %s introuvable",
+//		"USR-409":           "l'utilisateur %s existe déjà",
+//	})
+func AddMessages(locale string, messages map[string]string) {
+	locale = normalizeLocale(locale)
+	messageCatalogs.mutex.Lock()
+	defer messageCatalogs.mutex.Unlock()
+	catalog, found := messageCatalogs.catalogs[locale]
+	if !found {
+		catalog = make(map[string]string, len(messages))
+		messageCatalogs.catalogs[locale] = catalog
+	}
+	for key, format := range messages {
+		catalog[key] = format
+	}
+}
+
+// Localize returns the error message followed by causes messages on one line (see `%v` format),
+// localized with the message catalogs of the locale. Field errors, aggregated errors and causes of
+// foreign wrappers (example: fmt.Errorf with %w verb) are localized too.
+//
+// If a message is not found for the locale (example: "fr-CA"), the message of the language ("fr") is used.
+// Otherwise, the default message is used.
+func Localize(err error, locale string) string {
+	if err == nil {
+		return ""
+	}
+	return oneLineMessageOf(err, normalizeLocale(locale))
+}
+
+// localizedMessage returns the error message, localized with the message catalogs of the locale.
+// The default message is returned if locale is empty or if message is not found.
+func (err *customError) localizedMessage(locale string) string {
+	if locale == "" || err.key == "" {
+		return err.Message()
+	}
+	messageCatalogs.mutex.RLock()
+	defer messageCatalogs.mutex.RUnlock()
+	for {
+		if format, found := messageCatalogs.catalogs[locale][err.key]; found {
+			return fmt.Sprintf(format, err.arguments...)
+		}
+		separator := strings.LastIndex(locale, "-")
+		if separator < 0 {
+			return err.Message()
+		}
+		locale = locale[:separator]
+	}
+}
+
+// normalizeLocale returns the lower case locale, with "-" separator ("fr_FR" -> "fr-fr").
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+}
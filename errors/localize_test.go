@@ -0,0 +1,110 @@
+package errors
+
+import (
+	"fmt"
+	"testing"
+)
+
+var errTestLocalizedConflict = Define("TEST-LOC-409", "user %s already exists")
+
+func TestLocalize(t *testing.T) {
+	AddMessages("fr", map[string]string{
+		"failed to save user %s": "échec de l'enregistrement de l'utilisateur %s",
+		"TEST-LOC-409":           "l'utilisateur %s existe déjà",
+	})
+	AddMessages("fr_CA", map[string]string{
+		"failed to save user %s": "impossible d'enregistrer l'utilisateur %s",
+	})
+	err := NewWithCause(Wrap(errTestLocalizedConflict.New("john")), "failed to save user %s", "john")
+	tests := []struct {
+		name   string
+		err    error
+		locale string
+		want   string
+	}{
+		{
+			name:   "language catalog",
+			err:    err,
+			locale: "fr",
+			want:   "échec de l'enregistrement de l'utilisateur john: l'utilisateur john existe déjà",
+		},
+		{
+			name:   "locale catalog with language fallback",
+			err:    err,
+			locale: "fr-CA",
+			want:   "impossible d'enregistrer l'utilisateur john: l'utilisateur john existe déjà",
+		},
+		{
+			name:   "region fallback",
+			err:    err,
+			locale: "FR_be",
+			want:   "échec de l'enregistrement de l'utilisateur john: l'utilisateur john existe déjà",
+		},
+		{
+			name:   "default messages",
+			err:    err,
+			locale: "de",
+			want:   "failed to save user john: user john already exists",
+		},
+		{
+			name:   "foreign error",
+			err:    fmt.Errorf("foreign error"),
+			locale: "fr",
+			want:   "foreign error",
+		},
+		{
+			name:   "foreign wrapper",
+			err:    fmt.Errorf("request rejected: %w", err),
+			locale: "fr",
+			want:   "request rejected: échec de l'enregistrement de l'utilisateur john: l'utilisateur john existe déjà",
+		},
+		{
+			name:   "aggregated errors",
+			err:    Append(errTestLocalizedConflict.New("john"), fmt.Errorf("foreign error")),
+			locale: "fr",
+			want:   "2 error(s) occurred: [l'utilisateur john existe déjà; foreign error]",
+		},
+		{
+			name:   "field errors",
+			err:    localizedValidationError(),
+			locale: "fr",
+			want:   "invalid input, 1 field error(s) found: [name: l'utilisateur john existe déjà]",
+		},
+		{
+			name:   "nil error",
+			err:    nil,
+			locale: "fr",
+			want:   "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Localize(tt.err, tt.locale); got != tt.want {
+				t.Errorf("Localize() = '%v', want '%v'", got, tt.want)
+			}
+		})
+	}
+	if got := err.(TraceableError).Message(); got != "failed to save user john" {
+		t.Errorf("Message() = '%v', want default message", got)
+	}
+}
+
+func TestLocalize_mutableArguments(t *testing.T) {
+	AddMessages("fr", map[string]string{"invalid users %v": "utilisateurs invalides %v"})
+	users := []string{"john"}
+	err := New("invalid users %v", users)
+	users[0] = "jane"
+	if got := err.(TraceableError).Message(); got != "invalid users [john]" {
+		t.Errorf("Message() = '%v', want 'invalid users [john]'", got)
+	}
+	if got := Localize(err, "fr"); got != "utilisateurs invalides [john]" {
+		t.Errorf("Localize() = '%v', want 'utilisateurs invalides [john]'", got)
+	}
+}
+
+// localizedValidationError returns a validation error with a localizable field error.
+func localizedValidationError() error {
+	validation := NewValidationError()
+	validation.AddError("name", errTestLocalizedConflict.New("john"))
+	return validation.Err()
+}
@@ -0,0 +1,99 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MultiError is an error aggregating several errors (example: validation failures).
+// Members are returned by `Unwrap() []error` method: `errors.Is` and `errors.As` functions
+// of standard errors package check every member.
+type MultiError struct {
+	// errors contains the aggregated errors, in append order.
+	errors []error
+	// file is the filename.
+	file string
+	// function is the name of function in file.
+	function string
+	// line is the line in file.
+	line int
+}
+
+// Append adds the errors to the aggregated error, and returns the aggregated error.
+// Nil errors are ignored, typed nil errors included (example: nil *MultiError).
+//
+// If err is a *MultiError, a copy of it is returned with the errors added: err is not modified.
+// Otherwise, a new *MultiError is created with err and errors, at the caller position.
+// Function returns nil if there is no error to aggregate:
+//
+//	var err error
+//	for _, user := range users {
+//		err = errors.Append(err, validate(user))
+//	}
+//	return err
+func Append(err error, errs ...error) error {
+	multiError, ok := err.(*MultiError)
+	if !ok || multiError == nil {
+		members := appendNotNilErrors(make([]error, 0, len(errs)+1), []error{err})
+		members = appendNotNilErrors(members, errs)
+		if len(members) == 0 {
+			return nil
+		}
+		file, function, line := extractPositionInExecutionStack(1)
+		return &MultiError{errors: members, file: file, function: function, line: line}
+	}
+	members := make([]error, 0, len(multiError.errors)+len(errs))
+	members = append(members, multiError.errors...)
+	copied := *multiError
+	copied.errors = appendNotNilErrors(members, errs)
+	return &copied
+}
+
+// appendNotNilErrors adds the not nil errors to the slice. Typed nil errors are ignored (see isNil function).
+func appendNotNilErrors(members []error, errs []error) []error {
+	for _, err := range errs {
+		if !isNil(err) {
+			members = append(members, err)
+		}
+	}
+	return members
+}
+
+// Errors returns the aggregated errors, in append order.
+func (err *MultiError) Errors() []error {
+	return err.errors
+}
+
+// Message returns the error description, without aggregated errors.
+func (err *MultiError) Message() string {
+	return fmt.Sprintf("%d error(s) occurred", len(err.errors))
+}
+
+// Error returns the error description with the aggregated errors tree.
+func (err *MultiError) Error() string {
+	message := err.Message() + formatPosition(err.file, err.function, err.line)
+	for _, member := range err.errors {
+		message += "\n  - " + strings.ReplaceAll(member.Error(), "\n", "\n    ")
+	}
+	return message
+}
+
+// Unwrap method returns the aggregated errors. Multiple errors wrapper method.
+func (err *MultiError) Unwrap() []error {
+	return err.errors
+}
+
+// File method returns error filename.
+func (err *MultiError) File() string {
+	return err.file
+}
+
+// Function method returns function of error in file.
+func (err *MultiError) Function() string {
+	return err.function
+}
+
+// Line method returns line number of error in file.
+func (err *MultiError) Line() int {
+	return err.line
+}
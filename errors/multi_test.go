@@ -0,0 +1,121 @@
+package errors
+
+import (
+	goerr "errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestAppend(t *testing.T) {
+	first := fmt.Errorf("first error")
+	second := fmt.Errorf("second error")
+	tests := []struct {
+		name        string
+		err         error
+		errs        []error
+		wantMembers []error
+	}{
+		{
+			name:        "nil errors",
+			err:         nil,
+			errs:        []error{nil, nil},
+			wantMembers: nil,
+		},
+		{
+			name:        "new aggregated error",
+			err:         first,
+			errs:        []error{nil, second},
+			wantMembers: []error{first, second},
+		},
+		{
+			name:        "nil initial error",
+			err:         nil,
+			errs:        []error{first},
+			wantMembers: []error{first},
+		},
+		{
+			name:        "existing aggregated error",
+			err:         Append(nil, first),
+			errs:        []error{second},
+			wantMembers: []error{first, second},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Append(tt.err, tt.errs...)
+			if tt.wantMembers == nil {
+				if err != nil {
+					t.Errorf("Append() = '%v', want nil", err)
+				}
+				return
+			}
+			multiError, ok := err.(*MultiError)
+			if !ok {
+				t.Fatalf("Append() = '%v', want *MultiError", err)
+			}
+			if len(multiError.Errors()) != len(tt.wantMembers) {
+				t.Fatalf("Errors() = '%v', want '%v'", multiError.Errors(), tt.wantMembers)
+			}
+			for index, member := range multiError.Errors() {
+				if member != tt.wantMembers[index] {
+					t.Errorf("Errors()[%d] = '%v', want '%v'", index, member, tt.wantMembers[index])
+				}
+			}
+		})
+	}
+}
+
+func TestAppend_doesNotModifyAggregatedError(t *testing.T) {
+	err := Append(nil, fmt.Errorf("first error"))
+	appended := Append(err, fmt.Errorf("second error"))
+	if len(err.(*MultiError).Errors()) != 1 {
+		t.Errorf("Errors() = '%v', want only first error", err.(*MultiError).Errors())
+	}
+	if len(appended.(*MultiError).Errors()) != 2 || appended == err {
+		t.Errorf("Append() = '%v', want new aggregated error with 2 errors", appended)
+	}
+}
+
+func TestAppend_typedNil(t *testing.T) {
+	var multiError *MultiError
+	second := fmt.Errorf("second error")
+	err := Append(multiError, nil, second, (*MultiError)(nil))
+	members := err.(*MultiError).Errors()
+	if len(members) != 1 || members[0] != second {
+		t.Fatalf("Append() members = '%v', want only second error", members)
+	}
+	if !strings.Contains(err.Error(), "second error") {
+		t.Errorf("Error() = '%v', want second error", err.Error())
+	}
+	if Append(multiError) != nil {
+		t.Errorf("Append() of typed nil = '%v', want nil", Append(multiError))
+	}
+}
+
+func TestMultiError_Is(t *testing.T) {
+	err := Append(New("first error"), WithCode(New("second error"), codeUserNotFound))
+	if !goerr.Is(err, codeUserNotFound) {
+		t.Errorf("Is() = false, want true")
+	}
+	var traceable TraceableError
+	if !goerr.As(err, &traceable) || traceable.Message() != "first error" {
+		t.Errorf("As() = '%v', want first error", traceable)
+	}
+}
+
+func TestMultiError_Error(t *testing.T) {
+	nested := Append(fmt.Errorf("nested error"), fmt.Errorf("multi\nline error"))
+	err := Append(fmt.Errorf("first error"), nested).(*MultiError)
+	if err.Function() != "github.com/deverdeb/bvmgo-util/errors.TestMultiError_Error" ||
+		!strings.HasSuffix(err.File(), "multi_test.go") || err.Line() <= 0 {
+		t.Errorf("position = '%v:%v', want TestMultiError_Error position", err.Function(), err.Line())
+	}
+	want := "2 error(s) occurred ( at github.com/deverdeb/bvmgo-util/errors.TestMultiError_Error:"
+	wantMembers := "\n  - first error\n  - 2 error(s) occurred ( at github.com/deverdeb/bvmgo-util/errors.TestMultiError_Error:"
+	wantNested := " )\n      - nested error\n      - multi\n        line error"
+	message := err.Error()
+	if !strings.HasPrefix(message, want) || !strings.Contains(message, wantMembers) || !strings.HasSuffix(message, wantNested) {
+		t.Errorf("Error() = '%v'", message)
+	}
+}
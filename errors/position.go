@@ -0,0 +1,264 @@
+package errors
+
+import (
+	"path"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// PositionStyle defines how error positions and stack traces frames are rendered.
+type PositionStyle int
+
+const (
+	// PositionDefault keeps the default rendering of each formatter:
+	// function name for Error() method, full path for stack traces and file name for logs formatters.
+	PositionDefault PositionStyle = iota
+	// PositionFunction renders the package-qualified function name (example: "github.com/me/project/user.Load:12").
+	// Stack traces frames render the full path.
+	PositionFunction
+	// PositionFullPath renders the absolute source path (example: "/home/ci/project/user/load.go:12").
+	PositionFullPath
+	// PositionFileName renders the source file name (example: "load.go:12").
+	PositionFileName
+	// PositionRelativePath renders the source path without build machine prefix (example: "user/load.go:12").
+	// Trimmed prefixes are the configured prefixes (see AddTrimmedPathPrefix), the Go modules cache,
+	// the main module path of `-trimpath` builds and the module root directories (see debug.ReadBuildInfo).
+	PositionRelativePath
+)
+
+// modules contains the module paths of the build and the discovered module root directories.
+var modules = struct {
+	once  sync.Once
+	mutex sync.RWMutex
+	// mainPath is the main module path (example: "github.com/me/project"). Empty if build information is missing.
+	mainPath string
+	// paths contains the module paths of the build (main module and dependencies), longest first.
+	paths []string
+	// roots contains the discovered module root directories (with "/" separators), longest first.
+	roots []string
+}{}
+
+// loadModulePaths reads the module paths of the build information at first call.
+func loadModulePaths() {
+	modules.once.Do(func() {
+		info, ok := debug.ReadBuildInfo()
+		if !ok {
+			return
+		}
+		modules.mainPath = info.Main.Path
+		if info.Main.Path != "" {
+			modules.paths = append(modules.paths, info.Main.Path)
+		}
+		for _, dependency := range info.Deps {
+			modules.paths = append(modules.paths, dependency.Path)
+		}
+		sort.SliceStable(modules.paths, func(i, j int) bool { return len(modules.paths[i]) > len(modules.paths[j]) })
+	})
+}
+
+// modulePathOf returns the path of the module containing the package, or an empty string.
+func modulePathOf(packagePath string) string {
+	for _, modulePath := range modules.paths {
+		if packagePath == modulePath || strings.HasPrefix(packagePath, modulePath+"/") {
+			return modulePath
+		}
+	}
+	return ""
+}
+
+// packagePathOf returns the package path of the function ("github.com/me/project/user.(*Service).Load" ->
+// "github.com/me/project/user").
+func packagePathOf(function string) string {
+	lastSlash := strings.LastIndex(function, "/")
+	dot := strings.Index(function[lastSlash+1:], ".")
+	if dot < 0 {
+		return function
+	}
+	return function[:lastSlash+1+dot]
+}
+
+// discoverModuleRoot registers the root directory of the module of the function, deduced from the source file
+// directory and the package path in module ("/home/ci/project/user/load.go" + "github.com/me/project/user.Load"
+// with module "github.com/me/project" -> "/home/ci/project"). Main packages are ignored: their directory is unknown.
+func discoverModuleRoot(file string, function string) {
+	packagePath := packagePathOf(function)
+	modulePath := modulePathOf(packagePath)
+	if modulePath == "" || packagePath == "main" || !path.IsAbs(file) {
+		return
+	}
+	packageDirectory := strings.TrimPrefix(packagePath, modulePath)
+	directory := path.Dir(file)
+	if !strings.HasSuffix(directory, packageDirectory) {
+		return
+	}
+	root := strings.TrimSuffix(directory, packageDirectory)
+	modules.mutex.Lock()
+	defer modules.mutex.Unlock()
+	for _, existing := range modules.roots {
+		if existing == root {
+			return
+		}
+	}
+	modules.roots = append(modules.roots, root)
+	sort.SliceStable(modules.roots, func(i, j int) bool { return len(modules.roots[i]) > len(modules.roots[j]) })
+}
+
+// discoverModuleRootsInStack registers the module root directories of the functions of the current call stack.
+func discoverModuleRootsInStack() {
+	programCounters := make([]uintptr, 64)
+	frames := runtime.CallersFrames(programCounters[:runtime.Callers(1, programCounters)])
+	for {
+		frame, more := frames.Next()
+		if frame.Function != "" {
+			discoverModuleRoot(filepath.ToSlash(frame.File), frame.Function)
+		}
+		if !more {
+			return
+		}
+	}
+}
+
+// trimModuleRoot removes the discovered module root directory of the path.
+func trimModuleRoot(file string) (string, bool) {
+	modules.mutex.RLock()
+	defer modules.mutex.RUnlock()
+	for _, root := range modules.roots {
+		if strings.HasPrefix(file, root+"/") {
+			return file[len(root)+1:], true
+		}
+	}
+	return file, false
+}
+
+// positionConfiguration contains the positions rendering configuration.
+var positionConfiguration = struct {
+	mutex sync.RWMutex
+	// style is the configured style.
+	style PositionStyle
+	// trimmedPrefixes contains the configured trimmed prefixes.
+	trimmedPrefixes []string
+}{}
+
+// SetPositionStyle sets the rendering style of positions, for Error() method, stack traces and logs formatters.
+func SetPositionStyle(style PositionStyle) {
+	positionConfiguration.mutex.Lock()
+	defer positionConfiguration.mutex.Unlock()
+	positionConfiguration.style = style
+}
+
+// CurrentPositionStyle returns the rendering style of positions.
+func CurrentPositionStyle() PositionStyle {
+	positionConfiguration.mutex.RLock()
+	defer positionConfiguration.mutex.RUnlock()
+	return positionConfiguration.style
+}
+
+// AddTrimmedPathPrefix adds a prefix removed from paths by PositionRelativePath style
+// (example: "/home/ci/workspace/"). Longest prefixes are removed first.
+func AddTrimmedPathPrefix(prefix string) {
+	positionConfiguration.mutex.Lock()
+	defer positionConfiguration.mutex.Unlock()
+	positionConfiguration.trimmedPrefixes = append(positionConfiguration.trimmedPrefixes, prefix)
+	sort.SliceStable(positionConfiguration.trimmedPrefixes, func(i, j int) bool {
+		return len(positionConfiguration.trimmedPrefixes[i]) > len(positionConfiguration.trimmedPrefixes[j])
+	})
+}
+
+// ResetPositionConfiguration restores the default style and removes the configured trimmed prefixes.
+func ResetPositionConfiguration() {
+	positionConfiguration.mutex.Lock()
+	defer positionConfiguration.mutex.Unlock()
+	positionConfiguration.style = PositionDefault
+	positionConfiguration.trimmedPrefixes = nil
+}
+
+// FormatPosition returns the position description "location:line" with the configured style.
+// The fallback style is used if style is PositionDefault.
+// If the function is unknown, PositionFunction style renders the path with the fallback style
+// (the file name if fallback is PositionFunction, see FormatPath function).
+func FormatPosition(file string, function string, line int, fallback PositionStyle) string {
+	if effectiveStyle(fallback) == PositionFunction && function != "" && function != unknownFunctionLabel {
+		return function + ":" + strconv.Itoa(line)
+	}
+	return formatPath(file, function, fallback) + ":" + strconv.Itoa(line)
+}
+
+// FormatPath returns the source path with the configured style.
+// The fallback style is used if style is PositionDefault.
+// PositionFunction style renders the path with the fallback style (the file name if fallback is PositionFunction).
+func FormatPath(file string, fallback PositionStyle) string {
+	return formatPath(file, "", fallback)
+}
+
+// formatPath returns the source path with the configured style (see FormatPath function).
+// The function of the position (or an empty string if unknown) is used to find the module root directory.
+func formatPath(file string, function string, fallback PositionStyle) string {
+	style := effectiveStyle(fallback)
+	if style == PositionFunction {
+		// A path has no function rendering: fallback style is used, or the file name.
+		style = fallback
+		if style == PositionFunction || style == PositionDefault {
+			style = PositionFileName
+		}
+	}
+	switch style {
+	case PositionFileName:
+		return filepath.Base(file)
+	case PositionRelativePath:
+		if function == unknownFunctionLabel {
+			function = ""
+		}
+		return trimPathPrefix(file, function)
+	default:
+		return file
+	}
+}
+
+// effectiveStyle returns the configured style, or the fallback style if style is PositionDefault.
+func effectiveStyle(fallback PositionStyle) PositionStyle {
+	if style := CurrentPositionStyle(); style != PositionDefault {
+		return style
+	}
+	return fallback
+}
+
+// trimPathPrefix removes the build machine prefix of the path.
+// The function of the position (or an empty string if unknown) is used to find the module root directory.
+func trimPathPrefix(file string, function string) string {
+	file = filepath.ToSlash(file)
+	positionConfiguration.mutex.RLock()
+	prefixes := positionConfiguration.trimmedPrefixes
+	positionConfiguration.mutex.RUnlock()
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(file, filepath.ToSlash(prefix)) {
+			return strings.TrimPrefix(file[len(prefix):], "/")
+		}
+	}
+	// Go modules cache: ".../pkg/mod/github.com/me/project@v1.0.0/user/load.go"
+	if _, modulePath, found := strings.Cut(file, "/pkg/mod/"); found {
+		return modulePath
+	}
+	loadModulePaths()
+	if !path.IsAbs(file) && !filepath.IsAbs(file) {
+		// `-trimpath` build: "github.com/me/project/user/load.go", dependencies keep their module path and version.
+		if modules.mainPath != "" && strings.HasPrefix(file, modules.mainPath+"/") {
+			return file[len(modules.mainPath)+1:]
+		}
+		return file
+	}
+	if function != "" {
+		discoverModuleRoot(file, function)
+	}
+	if relative, found := trimModuleRoot(file); found {
+		return relative
+	}
+	// Unknown module root: the functions of the call stack may belong to the module (example: log call position).
+	discoverModuleRootsInStack()
+	relative, _ := trimModuleRoot(file)
+	return relative
+}
@@ -0,0 +1,136 @@
+package errors
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatPosition(t *testing.T) {
+	defer ResetPositionConfiguration()
+	AddTrimmedPathPrefix("/home/ci/")
+	AddTrimmedPathPrefix("/home/ci/workspace/")
+	tests := []struct {
+		name     string
+		style    PositionStyle
+		fallback PositionStyle
+		file     string
+		function string
+		want     string
+	}{
+		{
+			name:     "default style uses fallback",
+			style:    PositionDefault,
+			fallback: PositionFileName,
+			file:     "/home/ci/workspace/user/load.go",
+			function: "github.com/me/project/user.Load",
+			want:     "load.go:12",
+		},
+		{
+			name:     "function style",
+			style:    PositionFunction,
+			fallback: PositionFileName,
+			file:     "/home/ci/workspace/user/load.go",
+			function: "github.com/me/project/user.Load",
+			want:     "github.com/me/project/user.Load:12",
+		},
+		{
+			name:     "function style without function",
+			style:    PositionFunction,
+			fallback: PositionFileName,
+			file:     "/home/ci/workspace/user/load.go",
+			function: unknownFunctionLabel,
+			want:     "load.go:12",
+		},
+		{
+			name:     "function style without function uses fallback",
+			style:    PositionFunction,
+			fallback: PositionRelativePath,
+			file:     "/home/ci/workspace/user/load.go",
+			want:     "user/load.go:12",
+		},
+		{
+			name:     "function style without function nor fallback",
+			style:    PositionFunction,
+			fallback: PositionFunction,
+			file:     "/home/ci/workspace/user/load.go",
+			want:     "load.go:12",
+		},
+		{
+			name:     "full path style",
+			style:    PositionFullPath,
+			fallback: PositionFunction,
+			file:     "/home/ci/workspace/user/load.go",
+			function: "github.com/me/project/user.Load",
+			want:     "/home/ci/workspace/user/load.go:12",
+		},
+		{
+			name:     "relative path with longest configured prefix",
+			style:    PositionRelativePath,
+			fallback: PositionFunction,
+			file:     "/home/ci/workspace/user/load.go",
+			want:     "user/load.go:12",
+		},
+		{
+			name:     "relative path in modules cache",
+			style:    PositionRelativePath,
+			fallback: PositionFunction,
+			file:     "/root/go/pkg/mod/github.com/me/project@v1.0.0/user/load.go",
+			want:     "github.com/me/project@v1.0.0/user/load.go:12",
+		},
+		{
+			name:     "relative path of trimpath build",
+			style:    PositionRelativePath,
+			fallback: PositionFunction,
+			file:     "github.com/deverdeb/bvmgo-util/user/load.go",
+			want:     "user/load.go:12",
+		},
+		{
+			name:     "relative path in module root directory",
+			style:    PositionRelativePath,
+			fallback: PositionFunction,
+			file:     "/opt/build/bvmgo-util/user/load.go",
+			function: "github.com/deverdeb/bvmgo-util/user.(*Service).Load",
+			want:     "user/load.go:12",
+		},
+		{
+			name:     "relative path without known prefix",
+			style:    PositionRelativePath,
+			fallback: PositionFunction,
+			file:     "/opt/sources/load.go",
+			want:     "/opt/sources/load.go:12",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetPositionStyle(tt.style)
+			if got := FormatPosition(tt.file, tt.function, 12, tt.fallback); got != tt.want {
+				t.Errorf("FormatPosition() = '%v', want '%v'", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetPositionStyle(t *testing.T) {
+	defer ResetPositionConfiguration()
+	err := WithStack(New("error message"))
+	SetPositionStyle(PositionRelativePath)
+	if got := err.Error(); !strings.HasPrefix(got, "error message ( at errors/position_test.go:") {
+		t.Errorf("Error() = '%v', want module root relative position", got)
+	}
+	if frame := err.(StackTracer).StackTrace().Frames()[0]; !strings.Contains(frame.String(), " ( errors/position_test.go:") {
+		t.Errorf("StackFrame.String() = '%v', want module root relative path", frame)
+	}
+	if got := FormatPath(err.(TraceableError).File(), PositionFileName); got != "errors/position_test.go" {
+		t.Errorf("FormatPath() = '%v', want module root relative path", got)
+	}
+	if CurrentPositionStyle() != PositionRelativePath {
+		t.Errorf("CurrentPositionStyle() = %v, want %v", CurrentPositionStyle(), PositionRelativePath)
+	}
+	SetPositionStyle(PositionFunction)
+	if got := FormatPath(err.(TraceableError).File(), PositionFileName); got != "position_test.go" {
+		t.Errorf("FormatPath() = '%v', want fallback style path", got)
+	}
+	if frame := err.(StackTracer).StackTrace().Frames()[0]; !strings.Contains(frame.String(), " ( "+frame.File+":") {
+		t.Errorf("StackFrame.String() = '%v', want full path", frame)
+	}
+}
@@ -0,0 +1,207 @@
+// Package problem renders errors as RFC 7807 problem details (`application/problem+json`) for HTTP handlers.
+package problem
+
+import (
+	"encoding/json"
+	goerr "errors"
+	"fmt"
+	"github.com/deverdeb/bvmgo-util/errors"
+	"github.com/deverdeb/bvmgo-util/logs"
+	"net/http"
+)
+
+// ContentType is the content type of problem details documents.
+const ContentType = "application/problem+json"
+
+// Problem is a RFC 7807 problem details document.
+// File, Function, Line, Causes and Attributes members are only written in debug mode (see Renderer.Debug).
+type Problem struct {
+	// Type is a URI reference which identifies the problem type. "about:blank" if omitted.
+	Type string `json:"type,omitempty"`
+	// Title is a short summary of the problem type.
+	Title string `json:"title"`
+	// Status is the HTTP status code.
+	Status int `json:"status"`
+	// Detail is the explanation of this occurrence of the problem.
+	Detail string `json:"detail,omitempty"`
+	// Instance is the URI reference of this occurrence of the problem.
+	Instance string `json:"instance,omitempty"`
+	// Code is the machine-readable error code (see errors.WithCode).
+	Code errors.Code `json:"code,omitempty"`
+	// File is the error filename (debug mode).
+	File string `json:"file,omitempty"`
+	// Function is the error function (debug mode).
+	Function string `json:"function,omitempty"`
+	// Line is the error line in file (debug mode).
+	Line int `json:"line,omitempty"`
+	// Causes contains the causes descriptions (debug mode).
+	Causes []string `json:"causes,omitempty"`
+	// Attributes contains the error chain attributes (debug mode).
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// Renderer converts errors to problem details documents.
+// HTTP status is found with the error code mapping, then with the error kind mapping.
+// Errors without mapped code or kind are internal server errors.
+type Renderer struct {
+	// Debug is true if internal details (position, causes and attributes) are written.
+	// In production mode (false), details of internal server errors are not written.
+	Debug bool
+	// statusByKind contains the HTTP status of error kinds.
+	statusByKind map[errors.Kind]int
+	// statusByCode contains the HTTP status of error codes.
+	statusByCode map[errors.Code]int
+}
+
+// NewRenderer creates a renderer in production mode, with the default kinds mapping:
+// errors.KindNotFound (404), errors.KindConflict (409), errors.KindInvalidInput (400)
+// and errors.KindUnavailable (503).
+func NewRenderer() *Renderer {
+	return &Renderer{
+		statusByKind: map[errors.Kind]int{
+			errors.KindNotFound:     http.StatusNotFound,
+			errors.KindConflict:     http.StatusConflict,
+			errors.KindInvalidInput: http.StatusBadRequest,
+			errors.KindUnavailable:  http.StatusServiceUnavailable,
+		},
+		statusByCode: make(map[errors.Code]int),
+	}
+}
+
+// SetKindStatus sets the HTTP status of errors of the kind.
+func (renderer *Renderer) SetKindStatus(kind errors.Kind, status int) {
+	renderer.statusByKind[kind] = status
+}
+
+// SetCodeStatus sets the HTTP status of errors with the code. Codes mapping has priority over kinds mapping.
+func (renderer *Renderer) SetCodeStatus(code errors.Code, status int) {
+	renderer.statusByCode[code] = status
+}
+
+// Status returns the HTTP status of the error.
+func (renderer *Renderer) Status(err error) int {
+	if status, found := renderer.statusByCode[errors.CodeOf(err)]; found {
+		return status
+	}
+	if status, found := renderer.statusByKind[errors.KindOf(err)]; found {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// Problem converts the error to a problem details document.
+// In production mode, detail is the user-safe message of the error chain (see errors.SafeMessage):
+// internal messages are never written.
+func (renderer *Renderer) Problem(err error) *Problem {
+	status := renderer.Status(err)
+	problem := &Problem{
+		Title:  http.StatusText(status),
+		Status: status,
+		Code:   errors.CodeOf(err),
+	}
+	if renderer.Debug {
+		problem.Detail = fmt.Sprintf("%v", err)
+		renderer.addDebugDetails(problem, err)
+	} else {
+		problem.Detail = errors.SafeMessage(err)
+	}
+	return problem
+}
+
+// addDebugDetails adds error position, causes and attributes to problem.
+func (renderer *Renderer) addDebugDetails(problem *Problem, err error) {
+	if traceable, ok := err.(errors.TraceableError); ok {
+		problem.File = errors.FormatPath(traceable.File(), errors.PositionFileName)
+		problem.Function = traceable.Function()
+		problem.Line = traceable.Line()
+	}
+	for cause := goerr.Unwrap(err); cause != nil; cause = goerr.Unwrap(cause) {
+		if traceable, ok := cause.(errors.TraceableError); ok {
+			problem.Causes = append(problem.Causes, fmt.Sprintf("%s ( %s )", traceable.Message(),
+				errors.FormatPosition(traceable.File(), traceable.Function(), traceable.Line(), errors.PositionFileName)))
+		} else {
+			problem.Causes = append(problem.Causes, cause.Error())
+		}
+	}
+	if attributes := errors.AttributesOf(err); len(attributes) > 0 {
+		problem.Attributes = make(map[string]interface{}, len(attributes))
+		for _, attribute := range attributes {
+			problem.Attributes[attribute.Key] = attribute.Value
+		}
+	}
+}
+
+// Write writes the problem details document of the error in HTTP response.
+// If the response was already started by the handler (writer of Middleware function), the problem is only logged:
+// status and body cannot be replaced.
+func (renderer *Renderer) Write(writer http.ResponseWriter, request *http.Request, err error) {
+	if tracked, ok := writer.(*responseWriter); ok && tracked.started {
+		logs.DefaultLogger().Error("failed to write problem details: response already started", err)
+		return
+	}
+	problem := renderer.Problem(err)
+	if request != nil {
+		problem.Instance = request.URL.Path
+	}
+	writer.Header().Set("Content-Type", ContentType)
+	writer.WriteHeader(problem.Status)
+	if err := json.NewEncoder(writer).Encode(problem); err != nil {
+		logs.DefaultLogger().Error("failed to write problem details", err)
+	}
+}
+
+// Middleware returns a handler which recovers panics of next handler
+// and writes them as internal server errors.
+// The error is built at the panic position, with a *errors.PanicError cause (see errors.RecoverWith function)
+// and the request path as "path" attribute.
+// If next handler already started the response, the panic is only logged.
+// http.ErrAbortHandler panics are not recovered.
+func (renderer *Renderer) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		tracked := &responseWriter{ResponseWriter: writer}
+		defer errors.RecoverWith(func(err error) {
+			var panicErr *errors.PanicError
+			if goerr.As(err, &panicErr) && panicErr.Value() == http.ErrAbortHandler {
+				panic(http.ErrAbortHandler)
+			}
+			err = errors.With(err, "path", request.URL.Path)
+			logs.DefaultLogger().Error("HTTP handler failed", err)
+			if !tracked.started {
+				renderer.Write(writer, request, err)
+			}
+		})
+		next.ServeHTTP(tracked, request)
+	})
+}
+
+// responseWriter is a http.ResponseWriter which tracks if the response is started (header or body written).
+type responseWriter struct {
+	http.ResponseWriter
+	// started is true if the response header was written.
+	started bool
+}
+
+// WriteHeader writes the response header. http.ResponseWriter interface method.
+func (writer *responseWriter) WriteHeader(status int) {
+	writer.started = true
+	writer.ResponseWriter.WriteHeader(status)
+}
+
+// Write writes the response body. http.ResponseWriter interface method.
+func (writer *responseWriter) Write(data []byte) (int, error) {
+	writer.started = true
+	return writer.ResponseWriter.Write(data)
+}
+
+// Flush sends buffered data to the client if the wrapped writer supports it. http.Flusher interface method.
+func (writer *responseWriter) Flush() {
+	if flusher, ok := writer.ResponseWriter.(http.Flusher); ok {
+		writer.started = true
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the wrapped writer (see http.ResponseController).
+func (writer *responseWriter) Unwrap() http.ResponseWriter {
+	return writer.ResponseWriter
+}
@@ -0,0 +1,167 @@
+package problem
+
+import (
+	"encoding/json"
+	goerr "errors"
+	"fmt"
+	"github.com/deverdeb/bvmgo-util/errors"
+	"github.com/deverdeb/bvmgo-util/logs"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestRenderer_Status(t *testing.T) {
+	renderer := NewRenderer()
+	renderer.SetCodeStatus("QUOTA_EXCEEDED", http.StatusTooManyRequests)
+	renderer.SetKindStatus(errors.KindConflict, http.StatusPreconditionFailed)
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{
+			name: "default kind mapping",
+			err:  errors.WithKind(errors.New("user not found"), errors.KindNotFound),
+			want: http.StatusNotFound,
+		},
+		{
+			name: "configured kind mapping",
+			err:  errors.Wrap(errors.WithKind(errors.New("version conflict"), errors.KindConflict)),
+			want: http.StatusPreconditionFailed,
+		},
+		{
+			name: "code mapping",
+			err:  errors.WithKind(errors.WithCode(errors.New("quota exceeded"), "QUOTA_EXCEEDED"), errors.KindUnavailable),
+			want: http.StatusTooManyRequests,
+		},
+		{
+			name: "unknown error",
+			err:  fmt.Errorf("unknown error"),
+			want: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := renderer.Status(tt.err); got != tt.want {
+				t.Errorf("Status() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderer_Problem(t *testing.T) {
+	_, _, line, _ := runtime.Caller(0)
+	cause := errors.New("sql: no rows")
+	err := errors.With(errors.WithKind(errors.NewWithCause(cause, "user not found"), errors.KindNotFound), "userId", 42)
+	causeLine, errLine := line+1, line+2
+	renderer := NewRenderer()
+	problem := renderer.Problem(err)
+	if problem.Status != http.StatusNotFound || problem.Title != "Not Found" || problem.Detail != errors.GenericMessage ||
+		problem.File != "" || problem.Line != 0 || problem.Causes != nil || problem.Attributes != nil {
+		t.Errorf("Problem() = '%+v', want production problem", problem)
+	}
+	if problem := renderer.Problem(errors.New("database password is invalid")); problem.Detail != errors.GenericMessage {
+		t.Errorf("Problem() detail = '%v', want generic message for internal errors", problem.Detail)
+	}
+	notFound := errors.WithPublicMessage(err, "user %d not found", 42)
+	if problem := renderer.Problem(notFound); problem.Status != http.StatusNotFound || problem.Detail != "user 42 not found" {
+		t.Errorf("Problem() = '%+v', want public message as detail", problem)
+	}
+	public := errors.WithPublicMessage(errors.New("database password is invalid"), "service unavailable")
+	if problem := renderer.Problem(public); problem.Status != http.StatusInternalServerError || problem.Detail != "service unavailable" {
+		t.Errorf("Problem() = '%+v', want public message as detail", problem)
+	}
+	validation := errors.NewValidationError()
+	validation.Add("name", "REQUIRED", "name is required")
+	if problem := renderer.Problem(validation.Err()); problem.Status != http.StatusBadRequest || problem.Detail != "name: name is required" {
+		t.Errorf("Problem() = '%+v', want field errors as detail", problem)
+	}
+	renderer.Debug = true
+	problem = renderer.Problem(err)
+	if problem.Detail != "user not found: sql: no rows" || problem.File != "problem_test.go" || problem.Line != errLine ||
+		problem.Function != "github.com/deverdeb/bvmgo-util/errors/problem.TestRenderer_Problem" ||
+		len(problem.Causes) != 1 || problem.Causes[0] != fmt.Sprintf("sql: no rows ( problem_test.go:%d )", causeLine) ||
+		problem.Attributes["userId"] != 42 {
+		t.Errorf("Problem() = '%+v', want debug problem", problem)
+	}
+}
+
+func TestRenderer_Write(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	err := errors.WithPublicMessage(errors.New("user not found"), "user not found")
+	NewRenderer().Write(recorder, request, errors.WithCode(errors.WithKind(err, errors.KindNotFound), "USER_NOT_FOUND"))
+	if recorder.Code != http.StatusNotFound || recorder.Header().Get("Content-Type") != ContentType {
+		t.Errorf("Write() status = %v, content type = '%v'", recorder.Code, recorder.Header().Get("Content-Type"))
+	}
+	want := `{"title":"Not Found","status":404,"detail":"user not found","instance":"/users/42","code":"USER_NOT_FOUND"}`
+	if body := strings.TrimSpace(recorder.Body.String()); body != want {
+		t.Errorf("Write() body = '%v', want '%v'", body, want)
+	}
+}
+
+// entryRecorder is a log formatter which records the logged errors.
+type entryRecorder struct {
+	errors []error
+}
+
+// Format records the entry error. logs.Formatter interface method.
+func (recorder *entryRecorder) Format(entry *logs.Entry) string {
+	recorder.errors = append(recorder.errors, entry.Error)
+	return ""
+}
+
+func TestRenderer_Middleware(t *testing.T) {
+	logged := &entryRecorder{}
+	defer logs.DefaultLogger().SetFormatter(logs.DefaultLogger().Formatter())
+	logs.DefaultLogger().SetFormatter(logged)
+	renderer := NewRenderer()
+	renderer.Debug = true
+	_, _, line, _ := runtime.Caller(0)
+	handler := renderer.Middleware(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		panic("unexpected state")
+	}))
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/jobs", nil))
+	var problem Problem
+	if err := json.Unmarshal(recorder.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if recorder.Code != http.StatusInternalServerError || problem.Detail != "panic recovered: panic: unexpected state" ||
+		problem.Line != line+2 || problem.Function != "github.com/deverdeb/bvmgo-util/errors/problem.TestRenderer_Middleware.func1" ||
+		problem.Attributes["path"] != "/jobs" {
+		t.Errorf("Middleware() status = %v, problem = '%+v', want panic position", recorder.Code, problem)
+	}
+	var panicErr *errors.PanicError
+	if len(logged.errors) != 1 || !goerr.As(logged.errors[0], &panicErr) || panicErr.Value() != "unexpected state" {
+		t.Errorf("Middleware() logged errors = %v, want *errors.PanicError cause", logged.errors)
+	}
+}
+
+func TestRenderer_Middleware_abortHandler(t *testing.T) {
+	handler := NewRenderer().Middleware(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+	defer func() {
+		if recovered := recover(); recovered != http.ErrAbortHandler {
+			t.Errorf("Middleware() panic = %v, want http.ErrAbortHandler", recovered)
+		}
+	}()
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/jobs", nil))
+}
+
+func TestRenderer_Middleware_startedResponse(t *testing.T) {
+	handler := NewRenderer().Middleware(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusAccepted)
+		_, _ = writer.Write([]byte("partial"))
+		panic("unexpected state")
+	}))
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/jobs", nil))
+	if recorder.Code != http.StatusAccepted || recorder.Body.String() != "partial" {
+		t.Errorf("Middleware() status = %v, body = '%v', want started response unchanged", recorder.Code, recorder.Body.String())
+	}
+}
@@ -0,0 +1,90 @@
+package errors
+
+import (
+	goerr "errors"
+	"fmt"
+)
+
+// GenericMessage is the user-safe message of errors without public message (see SafeMessage).
+var GenericMessage = "an internal error occurred"
+
+// RedactedValue replaces the values of sensitive attributes in user-safe views (see SafeAttributes).
+const RedactedValue = "[REDACTED]"
+
+// WithPublicMessage returns the error with a user-safe message, shown to end users instead of the internal message.
+// The arguments complete the message format (see fmt.Sprintf method format).
+// Traceable errors are copied with the public message. Other errors are wrapped (see Wrap function).
+//
+//	err = errors.WithPublicMessage(errors.NewWithCause(sqlErr, "query %s failed", query), "user %s not found", id)
+func WithPublicMessage(err error, format string, attributes ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	result := copyOrWrapError(err, 1)
+	result.public = fmt.Sprintf(format, attributes...)
+	return result
+}
+
+// PublicMessage returns the public messages of the error chain, from the error to the root cause, separated by ": ".
+// Internal messages are never returned: an empty string is returned if no error of the chain has a public message.
+//
+// Field errors give "path: public message" (messages of ValidationError.Add method are public).
+// Public messages of aggregated errors (*MultiError, *ValidationError, `Unwrap() []error` errors)
+// are separated by "; ".
+func PublicMessage(err error) string {
+	message := ""
+	for ; err != nil; err = goerr.Unwrap(err) {
+		switch typedErr := err.(type) {
+		case *customError:
+			message = appendPublicMessage(message, typedErr.public, ": ")
+		case *FieldError:
+			// Field error chain is the chain of its traceable error.
+			if public := PublicMessage(typedErr.customError); public != "" {
+				message = appendPublicMessage(message, typedErr.path+": "+public, ": ")
+			}
+			return message
+		case interface{ Unwrap() []error }:
+			members := ""
+			for _, member := range typedErr.Unwrap() {
+				members = appendPublicMessage(members, PublicMessage(member), "; ")
+			}
+			return appendPublicMessage(message, members, ": ")
+		}
+	}
+	return message
+}
+
+// appendPublicMessage adds the public message to the message, with the separator.
+// Empty public messages and public messages equal to the message are ignored.
+func appendPublicMessage(message string, public string, separator string) string {
+	if public == "" || public == message {
+		return message
+	} else if message == "" {
+		return public
+	}
+	return message + separator + public
+}
+
+// SafeMessage returns the user-safe message of the error chain: public messages (see PublicMessage function),
+// or GenericMessage if no error of the chain has a public message. An empty string is returned if err is nil.
+func SafeMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	if message := PublicMessage(err); message != "" {
+		return message
+	}
+	return GenericMessage
+}
+
+// SafeAttributes returns the attributes of the error chain (see AttributesOf function),
+// with RedactedValue as value of sensitive attributes.
+func SafeAttributes(err error) []Attribute {
+	attributes := AttributesOf(err)
+	for idx, attribute := range attributes {
+		if attribute.Sensitive {
+			attributes[idx].Value = RedactedValue
+		}
+	}
+	return attributes
+}
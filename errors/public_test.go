@@ -0,0 +1,100 @@
+package errors
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestPublicMessage(t *testing.T) {
+	internal := NewWithCause(fmt.Errorf("pq: password authentication failed"), "query SELECT * FROM users failed")
+	tests := []struct {
+		name       string
+		err        error
+		wantPublic string
+		wantSafe   string
+	}{
+		{name: "nil error", err: nil, wantPublic: "", wantSafe: ""},
+		{name: "foreign error", err: fmt.Errorf("open /etc/secret: permission denied"), wantPublic: "", wantSafe: GenericMessage},
+		{name: "internal error", err: internal, wantPublic: "", wantSafe: GenericMessage},
+		{
+			name:       "public error",
+			err:        WithPublicMessage(internal, "user %s cannot be loaded", "bob"),
+			wantPublic: "user bob cannot be loaded",
+			wantSafe:   "user bob cannot be loaded",
+		},
+		{
+			name:       "public causes",
+			err:        WithPublicMessage(NewWithCause(WithPublicMessage(internal, "database unavailable"), "load failed"), "user cannot be loaded"),
+			wantPublic: "user cannot be loaded: database unavailable",
+			wantSafe:   "user cannot be loaded: database unavailable",
+		},
+		{
+			name:       "wrapped public error",
+			err:        Wrap(WithPublicMessage(fmt.Errorf("token abc expired"), "session expired")),
+			wantPublic: "session expired",
+			wantSafe:   "session expired",
+		},
+		{
+			name:       "validation error",
+			err:        validationErrorForPublicMessage(),
+			wantPublic: "name: name is required; address: zip code is invalid",
+			wantSafe:   "name: name is required; address: zip code is invalid",
+		},
+		{
+			name:       "wrapped field error",
+			err:        fmt.Errorf("request rejected: %w", validationErrorForPublicMessage().(*ValidationError).Errors()[0]),
+			wantPublic: "name: name is required",
+			wantSafe:   "name: name is required",
+		},
+		{
+			name:       "multiple errors",
+			err:        Append(WithPublicMessage(internal, "database unavailable"), internal, WithPublicMessage(New("timeout"), "retry later")),
+			wantPublic: "database unavailable; retry later",
+			wantSafe:   "database unavailable; retry later",
+		},
+		{
+			name:     "field error without public message",
+			err:      fieldErrorWithoutPublicMessage(internal),
+			wantSafe: GenericMessage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PublicMessage(tt.err); got != tt.wantPublic {
+				t.Errorf("PublicMessage() = '%s', want '%s'", got, tt.wantPublic)
+			}
+			if got := SafeMessage(tt.err); got != tt.wantSafe {
+				t.Errorf("SafeMessage() = '%s', want '%s'", got, tt.wantSafe)
+			}
+		})
+	}
+}
+
+func TestSafeAttributes(t *testing.T) {
+	err := WithSensitive(With(New("authentication failed"), "user", "bob"), "token", "abc123")
+	wantSafe := []Attribute{{Key: "user", Value: "bob"}, {Key: "token", Value: RedactedValue, Sensitive: true}}
+	if got := SafeAttributes(err); !reflect.DeepEqual(got, wantSafe) {
+		t.Errorf("SafeAttributes() = %v, want %v", got, wantSafe)
+	}
+	wantInternal := []Attribute{{Key: "user", Value: "bob"}, {Key: "token", Value: "abc123", Sensitive: true}}
+	if got := AttributesOf(err); !reflect.DeepEqual(got, wantInternal) {
+		t.Errorf("AttributesOf() = %v, want %v", got, wantInternal)
+	}
+}
+
+// validationErrorForPublicMessage returns a validation error with a field error without public message.
+func validationErrorForPublicMessage() error {
+	validation := NewValidationError()
+	validation.Add("name", "REQUIRED", "name is required")
+	validation.AddError("password", fmt.Errorf("hash of %s failed", "secret"))
+	validation.AddError("address", WithPublicMessage(New("zip 0000 is not in table"), "zip code is invalid"))
+	return validation.Err()
+}
+
+// fieldErrorWithoutPublicMessage returns a field error of an internal error.
+func fieldErrorWithoutPublicMessage(err error) error {
+	validation := NewValidationError()
+	validation.AddError("user", err)
+	return validation.Errors()[0]
+}
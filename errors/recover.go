@@ -0,0 +1,112 @@
+package errors
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// PanicError is the cause of errors built from recovered panics. It contains the panic value.
+type PanicError struct {
+	// value is the panic value.
+	value interface{}
+}
+
+// Value returns the panic value.
+func (err *PanicError) Value() interface{} {
+	return err.value
+}
+
+// Error returns the panic description.
+func (err *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", err.value)
+}
+
+// Unwrap method returns the panic value if it is an error.
+func (err *PanicError) Unwrap() error {
+	if cause, ok := err.value.(error); ok {
+		return cause
+	}
+	return nil
+}
+
+// Recover converts a panic to an error. It must be deferred:
+//
+//	func process() (err error) {
+//		defer errors.Recover(&err)
+//		// ...
+//	}
+//
+// The error is a TraceableError at the panic position, with the panic stack trace and a *PanicError cause.
+// If there is no panic, the error is not modified.
+func Recover(err *error) {
+	if recovered := recover(); recovered != nil {
+		*err = newPanicError(recovered)
+	}
+}
+
+// RecoverWith sends a panic converted to error to the handler (see Recover). It must be deferred:
+//
+//	defer errors.RecoverWith(func(err error) { logger.Error("worker failed", err) })
+func RecoverWith(handler func(err error)) {
+	if recovered := recover(); recovered != nil {
+		handler(newPanicError(recovered))
+	}
+}
+
+// Safe calls the function and returns its error. A panic of function is returned as error (see Recover).
+func Safe(function func() error) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = newPanicError(recovered)
+		}
+	}()
+	return function()
+}
+
+// Go calls the function in a new goroutine.
+// The error returned by the function, or its panic converted to error, is sent to the handler.
+// Handler is not called if function succeeds.
+func Go(function func() error, handler func(err error)) {
+	go func() {
+		if err := Safe(function); err != nil {
+			handler(err)
+		}
+	}()
+}
+
+// GoChan calls the function in a new goroutine and returns a channel which receives the function result:
+// the returned error, its panic converted to error, or nil if function succeeds. Channel is closed after.
+func GoChan(function func() error) <-chan error {
+	result := make(chan error, 1)
+	go func() {
+		defer close(result)
+		result <- Safe(function)
+	}()
+	return result
+}
+
+// newPanicError builds the error of a recovered panic.
+// It must be called by the deferred function which recovered the panic.
+func newPanicError(recovered interface{}) error {
+	// skip 2 -> ignore newPanicError(...) and deferred function.
+	stack := captureStackTrace(2)
+	file, function, line := panicPosition(stack)
+	err := buildCustomError(&PanicError{value: recovered}, file, function, line, "panic recovered").(*customError)
+	err.stack = stack
+	return err
+}
+
+// panicPosition returns the position of the panic: the first frame of stack outside runtime package.
+func panicPosition(stack *StackTrace) (file string, function string, line int) {
+	frames := runtime.CallersFrames(stack.programCounters)
+	for {
+		frame, more := frames.Next()
+		if frame.Function != "" && !strings.HasPrefix(frame.Function, "runtime.") {
+			return frame.File, frame.Function, frame.Line
+		}
+		if !more {
+			return "", unknownFunctionLabel, 0
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package errors
+
+import (
+	goerr "errors"
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// panicInHelper panics with the value.
+func panicInHelper(value interface{}) {
+	_, _, line, _ := runtime.Caller(0)
+	panicInHelperLine = line + 2
+	panic(value)
+}
+
+// panicInHelperLine is the line of panic in panicInHelper function.
+var panicInHelperLine int
+
+// recoverPanic calls panicInHelper and returns the recovered error.
+func recoverPanic(value interface{}) (err error) {
+	defer Recover(&err)
+	panicInHelper(value)
+	return nil
+}
+
+func TestRecover(t *testing.T) {
+	panicCause := fmt.Errorf("panic cause")
+	tests := []struct {
+		name      string
+		value     interface{}
+		wantError string
+	}{
+		{
+			name:      "string panic",
+			value:     "unexpected state",
+			wantError: "panic recovered: panic: unexpected state",
+		},
+		{
+			name:      "error panic",
+			value:     panicCause,
+			wantError: "panic recovered: panic: panic cause",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := recoverPanic(tt.value)
+			if got := fmt.Sprintf("%v", err); got != tt.wantError {
+				t.Errorf("Recover() = '%v', want '%v'", got, tt.wantError)
+			}
+			var panicError *PanicError
+			if !goerr.As(err, &panicError) || panicError.Value() != tt.value {
+				t.Errorf("Recover() panic value = '%v', want '%v'", panicError, tt.value)
+			}
+			traceable := err.(*customError)
+			if traceable.Function() != "github.com/deverdeb/bvmgo-util/errors.panicInHelper" || traceable.Line() != panicInHelperLine {
+				t.Errorf("Recover() position = '%v:%v', want panicInHelper:%v", traceable.Function(), traceable.Line(), panicInHelperLine)
+			}
+			frames := traceable.StackTrace().Frames()
+			if len(frames) < 2 || frames[1].Function != "github.com/deverdeb/bvmgo-util/errors.recoverPanic" {
+				t.Errorf("Recover() stack trace = '%v'", traceable.StackTrace())
+			}
+		})
+	}
+	if !goerr.Is(recoverPanic(panicCause), panicCause) {
+		t.Errorf("Recover() must wrap error panic value")
+	}
+}
+
+func TestRecover_withoutPanic(t *testing.T) {
+	err := func() (err error) {
+		defer Recover(&err)
+		return New("function error")
+	}()
+	if err.(*customError).Message() != "function error" {
+		t.Errorf("Recover() = '%v', want function error", err)
+	}
+}
+
+func TestRecoverWith(t *testing.T) {
+	var recovered error
+	func() {
+		defer RecoverWith(func(err error) { recovered = err })
+		panicInHelper("handled panic")
+	}()
+	if recovered == nil || recovered.(*customError).Function() != "github.com/deverdeb/bvmgo-util/errors.panicInHelper" {
+		t.Errorf("RecoverWith() = '%v', want panic error", recovered)
+	}
+}
+
+func TestSafe(t *testing.T) {
+	if err := Safe(func() error { return nil }); err != nil {
+		t.Errorf("Safe() = '%v', want nil", err)
+	}
+	if err := Safe(func() error { panicInHelper("safe panic"); return nil }); fmt.Sprintf("%v", err) != "panic recovered: panic: safe panic" {
+		t.Errorf("Safe() = '%v', want panic error", err)
+	}
+}
+
+func TestGo(t *testing.T) {
+	errs := make(chan error, 1)
+	Go(func() error { panicInHelper("goroutine panic"); return nil }, func(err error) { errs <- err })
+	select {
+	case err := <-errs:
+		if fmt.Sprintf("%v", err) != "panic recovered: panic: goroutine panic" {
+			t.Errorf("Go() handler error = '%v', want panic error", err)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("Go() handler not called")
+	}
+}
+
+func TestGoChan(t *testing.T) {
+	if err := <-GoChan(func() error { return New("goroutine error") }); err == nil || err.(*customError).Message() != "goroutine error" {
+		t.Errorf("GoChan() = '%v', want goroutine error", err)
+	}
+	result := GoChan(func() error { return nil })
+	if err := <-result; err != nil {
+		t.Errorf("GoChan() = '%v', want nil", err)
+	}
+	if _, open := <-result; open {
+		t.Errorf("GoChan() channel must be closed")
+	}
+}
@@ -0,0 +1,170 @@
+// Package retry calls failing operations again, with exponential and jittered backoff.
+//
+// Errors are classified as retryable or permanent with their kind (see errors.WithKind)
+// or with marker interfaces (see Permanent function, Retryable and Temporary methods).
+package retry
+
+import (
+	"context"
+	goerr "errors"
+	"github.com/deverdeb/bvmgo-util/errors"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Policy defines how an operation is retried.
+type Policy struct {
+	// MaxAttempts is the maximum number of attempts, first attempt included.
+	// If MaxAttempts is 0 or less, operation is retried until success, permanent error or context end.
+	MaxAttempts int
+	// InitialDelay is the delay before the second attempt.
+	InitialDelay time.Duration
+	// MaxDelay is the maximum delay between two attempts. Delay is not limited if MaxDelay is 0.
+	MaxDelay time.Duration
+	// Multiplier is the factor applied to the delay after each attempt (exponential backoff).
+	Multiplier float64
+	// Jitter is the random variation ratio of delays, between 0 and 1 (0.2 -> delay +/- 20%).
+	Jitter float64
+	// Classifier returns true if the error is retryable. IsRetryable function is used if Classifier is nil.
+	Classifier func(err error) bool
+}
+
+// DefaultPolicy returns the default policy: 3 attempts, delays from 100ms to 5s doubled after each attempt,
+// with 20% jitter.
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxAttempts:  3,
+		InitialDelay: 100 * time.Millisecond,
+		MaxDelay:     5 * time.Second,
+		Multiplier:   2,
+		Jitter:       0.2,
+	}
+}
+
+// Do calls the operation until it succeeds, returns a permanent error, reaches policy maximum attempts,
+// or context ends.
+//
+// The returned error contains every attempt failure (see errors.MultiError).
+// The returned error and the attempt failures are positioned at the caller of Do function.
+// It keeps the code and the kind of the last attempt error.
+func Do(ctx context.Context, policy Policy, operation func(ctx context.Context) error) error {
+	classifier := policy.Classifier
+	if classifier == nil {
+		classifier = IsRetryable
+	}
+	var failures error
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		lastErr = operation(ctx)
+		if lastErr == nil {
+			return nil
+		}
+		failures = errors.Append(failures, errors.NewWithCauseSkip(1, lastErr, "attempt %d failed", attempt))
+		if !classifier(lastErr) {
+			return failure(failures, lastErr, "operation failed with permanent error after %d attempt(s)", attempt)
+		}
+		if policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts {
+			return failure(failures, lastErr, "operation failed after %d attempt(s)", attempt)
+		}
+		timer := time.NewTimer(policy.delay(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			failures = errors.Append(failures, ctx.Err())
+			return failure(failures, lastErr, "operation interrupted after %d attempt(s)", attempt)
+		case <-timer.C:
+		}
+	}
+}
+
+// failure builds the error of failed retries, with the code and the kind of last attempt error.
+// Error is positioned at the caller of Do function.
+func failure(failures error, lastErr error, format string, attempts int) error {
+	err := errors.NewWithCauseSkip(2, failures, format, attempts)
+	if code := errors.CodeOf(lastErr); code != "" {
+		err = errors.WithCode(err, code)
+	}
+	if kind := errors.KindOf(lastErr); kind != errors.KindUnknown {
+		err = errors.WithKind(err, kind)
+	}
+	return err
+}
+
+// delay returns the delay after the attempt. Delay is limited to the maximum time.Duration value.
+func (policy Policy) delay(attempt int) time.Duration {
+	multiplier := policy.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	delay := float64(policy.InitialDelay) * math.Pow(multiplier, float64(attempt-1))
+	if policy.Jitter > 0 {
+		delay += delay * policy.Jitter * (2*rand.Float64() - 1)
+	}
+	if policy.MaxDelay > 0 && delay > float64(policy.MaxDelay) {
+		delay = float64(policy.MaxDelay)
+	}
+	if math.IsNaN(delay) || delay <= 0 {
+		return 0
+	}
+	if delay >= math.MaxInt64 {
+		// Unlimited delay overflows after many attempts (+Inf included).
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(delay)
+}
+
+// permanentError is an error marked as not retryable.
+type permanentError struct {
+	// cause is the marked error.
+	cause error
+}
+
+// Error returns the cause description.
+func (err *permanentError) Error() string {
+	return err.cause.Error()
+}
+
+// Unwrap method returns cause error.
+func (err *permanentError) Unwrap() error {
+	return err.cause
+}
+
+// Permanent marks the error as not retryable. Nil error is not marked.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{cause: err}
+}
+
+// IsRetryable is the default errors classifier. Error is not retryable if:
+//   - it is marked with Permanent function,
+//   - it has a `Retryable() bool` or a `Temporary() bool` method which returns false,
+//   - it is a context error (context.Canceled or context.DeadlineExceeded),
+//   - its kind is errors.KindNotFound, errors.KindConflict or errors.KindInvalidInput.
+//
+// Other errors are retryable.
+func IsRetryable(err error) bool {
+	var permanent *permanentError
+	if goerr.As(err, &permanent) {
+		return false
+	}
+	var retryable interface{ Retryable() bool }
+	if goerr.As(err, &retryable) {
+		return retryable.Retryable()
+	}
+	var temporary interface{ Temporary() bool }
+	if goerr.As(err, &temporary) {
+		return temporary.Temporary()
+	}
+	if goerr.Is(err, context.Canceled) || goerr.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	switch errors.KindOf(err) {
+	case errors.KindNotFound, errors.KindConflict, errors.KindInvalidInput:
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,188 @@
+package retry
+
+import (
+	"context"
+	goerr "errors"
+	"fmt"
+	"github.com/deverdeb/bvmgo-util/errors"
+	"math"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// temporaryError is an error with a Temporary() marker method.
+type temporaryError struct {
+	temporary bool
+}
+
+func (err *temporaryError) Error() string {
+	return "temporary error"
+}
+
+func (err *temporaryError) Temporary() bool {
+	return err.temporary
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "unknown error", err: fmt.Errorf("unknown error"), want: true},
+		{name: "unavailable kind", err: errors.WithKind(errors.New("unavailable"), errors.KindUnavailable), want: true},
+		{name: "not found kind", err: errors.WithKind(errors.New("not found"), errors.KindNotFound), want: false},
+		{name: "invalid input kind", err: errors.Wrap(errors.WithKind(errors.New("invalid"), errors.KindInvalidInput)), want: false},
+		{name: "permanent error", err: Permanent(fmt.Errorf("permanent")), want: false},
+		{name: "temporary marker", err: fmt.Errorf("wrapped: %w", &temporaryError{temporary: true}), want: true},
+		{name: "not temporary marker", err: &temporaryError{temporary: false}, want: false},
+		{name: "canceled context", err: context.Canceled, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if Permanent(nil) != nil {
+		t.Errorf("Permanent(nil) != nil")
+	}
+}
+
+func TestDo(t *testing.T) {
+	policy := Policy{MaxAttempts: 3, InitialDelay: time.Millisecond, Multiplier: 2}
+	unavailable := errors.WithCode(errors.WithKind(errors.New("service unavailable"), errors.KindUnavailable), "DOWN")
+	tests := []struct {
+		name         string
+		failures     []error
+		wantAttempts int
+		wantMessage  string
+	}{
+		{
+			name:         "first attempt succeeds",
+			failures:     nil,
+			wantAttempts: 1,
+		},
+		{
+			name:         "third attempt succeeds",
+			failures:     []error{fmt.Errorf("first failure"), fmt.Errorf("second failure")},
+			wantAttempts: 3,
+		},
+		{
+			name:         "max attempts",
+			failures:     []error{unavailable, unavailable, unavailable, unavailable},
+			wantAttempts: 3,
+			wantMessage:  "operation failed after 3 attempt(s)",
+		},
+		{
+			name:         "permanent error",
+			failures:     []error{fmt.Errorf("first failure"), Permanent(fmt.Errorf("permanent failure"))},
+			wantAttempts: 2,
+			wantMessage:  "operation failed with permanent error after 2 attempt(s)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			err := Do(context.Background(), policy, func(ctx context.Context) error {
+				attempts++
+				if attempts <= len(tt.failures) {
+					return tt.failures[attempts-1]
+				}
+				return nil
+			})
+			if attempts != tt.wantAttempts {
+				t.Errorf("Do() attempts = %v, want %v", attempts, tt.wantAttempts)
+			}
+			if tt.wantMessage == "" {
+				if err != nil {
+					t.Errorf("Do() error = '%v', want nil", err)
+				}
+				return
+			}
+			traceable, ok := err.(errors.TraceableError)
+			if !ok || traceable.Message() != tt.wantMessage {
+				t.Fatalf("Do() error = '%v', want '%v'", err, tt.wantMessage)
+			}
+			attemptsFailures := traceable.Cause().(*errors.MultiError).Errors()
+			if len(attemptsFailures) != tt.wantAttempts {
+				t.Errorf("Do() failures = '%v', want %d failures", attemptsFailures, tt.wantAttempts)
+			}
+			for index, failure := range attemptsFailures {
+				if !goerr.Is(failure, tt.failures[index]) {
+					t.Errorf("Do() failure %d = '%v', want '%v'", index, failure, tt.failures[index])
+				}
+			}
+		})
+	}
+}
+
+func TestDo_codeAndKind(t *testing.T) {
+	policy := Policy{MaxAttempts: 2, InitialDelay: time.Millisecond}
+	err := Do(context.Background(), policy, func(ctx context.Context) error {
+		return errors.WithCode(errors.WithKind(errors.New("service unavailable"), errors.KindUnavailable), "DOWN")
+	})
+	if !goerr.Is(err, errors.Code("DOWN")) || errors.KindOf(err) != errors.KindUnavailable {
+		t.Errorf("Do() error code = '%v', kind = '%v'", errors.CodeOf(err), errors.KindOf(err))
+	}
+}
+
+func TestDo_contextDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	policy := Policy{InitialDelay: 5 * time.Millisecond, Multiplier: 1}
+	attempts := 0
+	err := Do(ctx, policy, func(ctx context.Context) error {
+		attempts++
+		return fmt.Errorf("failure")
+	})
+	if !goerr.Is(err, context.DeadlineExceeded) || attempts < 2 {
+		t.Errorf("Do() error = '%v' after %d attempts, want deadline exceeded", err, attempts)
+	}
+	if !strings.HasPrefix(err.(errors.TraceableError).Message(), "operation interrupted after ") {
+		t.Errorf("Do() error = '%v', want interrupted error", err)
+	}
+}
+
+func TestPolicy_delay(t *testing.T) {
+	policy := Policy{InitialDelay: 100 * time.Millisecond, MaxDelay: time.Second, Multiplier: 3, Jitter: 0.5}
+	for attempt, bounds := range map[int][2]time.Duration{
+		1: {50 * time.Millisecond, 150 * time.Millisecond},
+		2: {150 * time.Millisecond, 450 * time.Millisecond},
+		5: {time.Second, time.Second},
+	} {
+		if delay := policy.delay(attempt); delay < bounds[0] || delay > bounds[1] {
+			t.Errorf("delay(%d) = %v, want between %v and %v", attempt, delay, bounds[0], bounds[1])
+		}
+	}
+}
+
+func TestPolicy_delay_overflow(t *testing.T) {
+	policy := Policy{InitialDelay: time.Second, Multiplier: 2}
+	if delay := policy.delay(2000); delay != time.Duration(math.MaxInt64) {
+		t.Errorf("delay(2000) = %v, want %v", delay, time.Duration(math.MaxInt64))
+	}
+	policy.InitialDelay = 0
+	if delay := policy.delay(2000); delay != 0 {
+		t.Errorf("delay(2000) = %v, want 0", delay)
+	}
+}
+
+func TestDo_callerPosition(t *testing.T) {
+	policy := Policy{MaxAttempts: 2, InitialDelay: time.Millisecond}
+	_, _, line, _ := runtime.Caller(0)
+	err := Do(context.Background(), policy, func(ctx context.Context) error { return fmt.Errorf("failure") })
+	wantLine := line + 1
+	positions := []error{err}
+	positions = append(positions, err.(errors.TraceableError).Cause().(*errors.MultiError).Errors()...)
+	for _, positioned := range positions {
+		traceable := positioned.(errors.TraceableError)
+		if traceable.Line() != wantLine || traceable.Function() != "github.com/deverdeb/bvmgo-util/errors/retry.TestDo_callerPosition" {
+			t.Errorf("Do() error '%s' position = '%v:%v', want caller line %v",
+				traceable.Message(), traceable.Function(), traceable.Line(), wantLine)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package errors
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// maxStackDepth is the maximum number of captured stack frames.
+const maxStackDepth = 64
+
+// defaultStackTraceFilters are the function prefixes of frames always removed from stack traces.
+var defaultStackTraceFilters = []string{"runtime.", "testing."}
+
+// stackTraceCapture is true if errors capture the whole call stack (see EnableStackTrace).
+var stackTraceCapture atomic.Bool
+
+// stackTraceFilters contains the configured function prefixes of filtered frames (see AddStackTraceFilter).
+var stackTraceFilters = struct {
+	mutex    sync.RWMutex
+	prefixes []string
+}{}
+
+// EnableStackTrace enables or disables the capture of the whole call stack when an error is created or wrapped.
+// Capture is disabled by default: errors only record the error position.
+// See also WithStack function to capture the call stack of a single error.
+func EnableStackTrace(enabled bool) {
+	stackTraceCapture.Store(enabled)
+}
+
+// IsStackTraceEnabled returns true if errors capture the whole call stack.
+func IsStackTraceEnabled() bool {
+	return stackTraceCapture.Load()
+}
+
+// AddStackTraceFilter removes the frames of functions starting with the prefix from printed stack traces
+// (example: "github.com/myorg/mylib/internal.").
+// Frames of runtime and testing packages are always removed.
+func AddStackTraceFilter(prefix string) {
+	stackTraceFilters.mutex.Lock()
+	defer stackTraceFilters.mutex.Unlock()
+	stackTraceFilters.prefixes = append(stackTraceFilters.prefixes, prefix)
+}
+
+// ResetStackTraceFilters removes the filters added with AddStackTraceFilter.
+func ResetStackTraceFilters() {
+	stackTraceFilters.mutex.Lock()
+	defer stackTraceFilters.mutex.Unlock()
+	stackTraceFilters.prefixes = nil
+}
+
+// isFilteredFunction returns true if the function frames are removed from stack traces.
+func isFilteredFunction(function string) bool {
+	for _, prefix := range defaultStackTraceFilters {
+		if strings.HasPrefix(function, prefix) {
+			return true
+		}
+	}
+	stackTraceFilters.mutex.RLock()
+	defer stackTraceFilters.mutex.RUnlock()
+	for _, prefix := range stackTraceFilters.prefixes {
+		if strings.HasPrefix(function, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// WithStack returns the error with the call stack of the caller, even if stack trace capture is disabled.
+// Traceable errors are copied with the stack trace. Other errors are wrapped (see Wrap function).
+func WithStack(err error) error {
+	if err == nil {
+		return nil
+	}
+	result := copyOrWrapError(err, 1)
+	result.stack = captureStackTrace(1)
+	return result
+}
+
+// StackFrame is a frame of a stack trace.
+type StackFrame struct {
+	// File is the filename.
+	File string
+	// Function is the name of function in file.
+	Function string
+	// Line is the line in file.
+	Line int
+}
+
+// String returns the frame description. File path is rendered with the position style (see SetPositionStyle).
+func (frame StackFrame) String() string {
+	return fmt.Sprintf("%s ( %s:%d )", frame.Function, formatPath(frame.File, frame.Function, PositionFullPath), frame.Line)
+}
+
+// StackTrace is a captured call stack.
+// Capture only records program counters: frames are symbolized when they are read.
+type StackTrace struct {
+	// programCounters are the return program counters of the call stack.
+	programCounters []uintptr
+}
+
+// captureStackTrace captures the call stack.
+// The argument skip is the number of stack frames to ascend, with 0 identifying the caller of captureStackTrace.
+func captureStackTrace(skip int) *StackTrace {
+	programCounters := make([]uintptr, maxStackDepth)
+	// skip +2 -> ignore runtime.Callers(...) and captureStackTrace(...)
+	count := runtime.Callers(skip+2, programCounters)
+	return &StackTrace{programCounters: programCounters[:count]}
+}
+
+// Frames returns the symbolized frames, from the error position to the root of the call stack.
+// Filtered frames are removed (see AddStackTraceFilter).
+func (stack *StackTrace) Frames() []StackFrame {
+	if stack == nil {
+		return nil
+	}
+	frames := make([]StackFrame, 0, len(stack.programCounters))
+	callersFrames := runtime.CallersFrames(stack.programCounters)
+	for {
+		frame, more := callersFrames.Next()
+		if frame.Function != "" && !isFilteredFunction(frame.Function) {
+			frames = append(frames, StackFrame{File: frame.File, Function: frame.Function, Line: frame.Line})
+		}
+		if !more {
+			return frames
+		}
+	}
+}
+
+// String returns the stack trace description, one frame per line.
+func (stack *StackTrace) String() string {
+	frames := stack.Frames()
+	lines := make([]string, 0, len(frames))
+	for _, frame := range frames {
+		lines = append(lines, "at "+frame.String())
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,93 @@
+package errors
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// failInHelper builds an error in a shared helper.
+func failInHelper() error {
+	_, _, line, _ := runtime.Caller(0)
+	failInHelperLine = line + 2
+	return New("helper error")
+}
+
+// failInHelperLine is the line of error creation in failInHelper function.
+var failInHelperLine int
+
+func TestEnableStackTrace(t *testing.T) {
+	defer EnableStackTrace(false)
+	if err := New("error message").(*customError); err.StackTrace() != nil {
+		t.Errorf("StackTrace() = '%v', want nil", err.StackTrace())
+	}
+	EnableStackTrace(true)
+	if !IsStackTraceEnabled() {
+		t.Errorf("IsStackTraceEnabled() = false, want true")
+	}
+	err := failInHelper().(*customError)
+	frames := err.StackTrace().Frames()
+	if len(frames) != 2 {
+		t.Fatalf("Frames() = '%v', want 2 frames", frames)
+	}
+	if frames[0].Function != "github.com/deverdeb/bvmgo-util/errors.failInHelper" || frames[0].Line != failInHelperLine {
+		t.Errorf("Frames()[0] = '%v', want failInHelper frame", frames[0])
+	}
+	if frames[1].Function != "github.com/deverdeb/bvmgo-util/errors.TestEnableStackTrace" {
+		t.Errorf("Frames()[1] = '%v', want TestEnableStackTrace frame", frames[1])
+	}
+	if wrapped := Wrap(err).(*customError); wrapped.StackTrace() == nil {
+		t.Errorf("StackTrace() = nil, want stack trace of wrapped error")
+	}
+}
+
+func TestWithStack(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantMessage string
+	}{
+		{
+			name:        "traceable error",
+			err:         New("traceable error"),
+			wantMessage: "traceable error",
+		},
+		{
+			name:        "standard error",
+			err:         fmt.Errorf("standard error"),
+			wantMessage: "standard error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := WithStack(tt.err).(*customError)
+			if err.Message() != tt.wantMessage {
+				t.Errorf("Message() = '%v', want '%v'", err.Message(), tt.wantMessage)
+			}
+			stack := err.StackTrace().String()
+			if !strings.HasPrefix(stack, "at github.com/deverdeb/bvmgo-util/errors.TestWithStack.func1 ( ") {
+				t.Errorf("StackTrace() = '%v', want TestWithStack.func1 first frame", stack)
+			}
+		})
+	}
+	if WithStack(nil) != nil {
+		t.Errorf("WithStack(nil) != nil")
+	}
+}
+
+func TestAddStackTraceFilter(t *testing.T) {
+	defer ResetStackTraceFilters()
+	AddStackTraceFilter("github.com/deverdeb/bvmgo-util/errors.failInHelper")
+	err := WithStack(failInHelper()).(*customError)
+	for _, frame := range err.StackTrace().Frames() {
+		if strings.HasPrefix(frame.Function, "runtime.") || strings.HasPrefix(frame.Function, "testing.") ||
+			frame.Function == "github.com/deverdeb/bvmgo-util/errors.failInHelper" {
+			t.Errorf("Frames() contains filtered frame '%v'", frame)
+		}
+	}
+	ResetStackTraceFilters()
+	if !isFilteredFunction("runtime.goexit") || isFilteredFunction("github.com/deverdeb/bvmgo-util/errors.failInHelper") {
+		t.Errorf("ResetStackTraceFilters() must keep only default filters")
+	}
+}
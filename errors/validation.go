@@ -0,0 +1,201 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// FieldError is a validation error of a field.
+type FieldError struct {
+	*customError
+	// path is the field path (examples: "address.zip", "items[3].qty").
+	path string
+}
+
+// Path returns the field path (examples: "address.zip", "items[3].qty").
+func (err *FieldError) Path() string {
+	return err.path
+}
+
+// Message returns the field path and the error message.
+func (err *FieldError) Message() string {
+	return err.path + ": " + err.customError.Message()
+}
+
+// Error returns the field path and the error message with position and cause.
+func (err *FieldError) Error() string {
+	return err.path + ": " + err.customError.Error()
+}
+
+// Format implements fmt.Formatter interface (see customError format), with the field path as prefix.
+func (err *FieldError) Format(state fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if state.Flag('+') {
+			_, _ = io.WriteString(state, err.path+": "+err.detailedMessage())
+			return
+		}
+		_, _ = io.WriteString(state, err.path+": "+err.oneLineMessage(""))
+	case 's':
+		_, _ = io.WriteString(state, err.path+": "+err.oneLineMessage(""))
+	case 'q':
+		_, _ = fmt.Fprintf(state, "%q", err.path+": "+err.oneLineMessage(""))
+	default:
+		_, _ = fmt.Fprintf(state, "%%!%c(%s: %s)", verb, err.path, err.oneLineMessage(""))
+	}
+}
+
+// ValidationError collects the field errors of a validation (request, configuration...).
+// Its kind is KindInvalidInput.
+//
+// ValidationError has the TraceableError methods, except `Unwrap() error`:
+// field errors are returned by `Unwrap() []error` method (`errors.Is` and `errors.As` check every field error).
+type ValidationError struct {
+	// errors contains the field errors, in detection order.
+	errors []*FieldError
+	// file is the filename.
+	file string
+	// function is the name of function in file.
+	function string
+	// line is the line in file.
+	line int
+	// stack is the call stack of validation creation. It is nil if stack trace was not captured.
+	stack *StackTrace
+}
+
+// NewValidationError creates an empty validation error collector, at the caller position.
+//
+//	validation := errors.NewValidationError()
+//	if request.Name == "" {
+//		validation.Add("name", "REQUIRED", "name is required")
+//	}
+//	validation.AddError("address", validateAddress(request.Address))
+//	return validation.Err()
+func NewValidationError() *ValidationError {
+	file, function, line := extractPositionInExecutionStack(1)
+	validation := &ValidationError{errors: make([]*FieldError, 0), file: file, function: function, line: line}
+	if IsStackTraceEnabled() {
+		validation.stack = captureStackTrace(1)
+	}
+	return validation
+}
+
+// Add adds a field error with a code, at the caller position.
+// The arguments complete the message format (see fmt.Sprintf method format).
+// The message is the public message of the field error (see PublicMessage function): it is shown to end users.
+func (err *ValidationError) Add(path string, code Code, format string, attributes ...interface{}) {
+	fieldError := extractPositionAndBuildCustomError(nil, 1, format, attributes...).(*customError)
+	fieldError.code = code
+	fieldError.public = fieldError.message
+	err.errors = append(err.errors, &FieldError{customError: fieldError, path: path})
+}
+
+// AddError adds the error of a field. Nil error is ignored.
+//
+// Field errors of a *ValidationError or a *FieldError are added with the path as prefix
+// ("address" + "zip" -> "address.zip", "items" + "[3].qty" -> "items[3].qty"):
+// a validator can delegate the validation of a structure to another validator.
+// Other errors are added as field error of the path.
+func (err *ValidationError) AddError(path string, fieldErr error) {
+	switch typedErr := fieldErr.(type) {
+	case nil:
+		return
+	case *ValidationError:
+		for _, nested := range typedErr.errors {
+			err.errors = append(err.errors, &FieldError{customError: nested.customError, path: joinFieldPath(path, nested.path)})
+		}
+	case *FieldError:
+		err.errors = append(err.errors, &FieldError{customError: typedErr.customError, path: joinFieldPath(path, typedErr.path)})
+	default:
+		err.errors = append(err.errors, &FieldError{customError: copyOrWrapError(fieldErr, 1), path: path})
+	}
+}
+
+// joinFieldPath returns the field path with the prefix.
+func joinFieldPath(prefix string, path string) string {
+	if prefix == "" {
+		return path
+	} else if path == "" {
+		return prefix
+	} else if strings.HasPrefix(path, "[") {
+		return prefix + path
+	}
+	return prefix + "." + path
+}
+
+// Errors returns the field errors, in detection order.
+func (err *ValidationError) Errors() []*FieldError {
+	return err.errors
+}
+
+// Err returns the validation error if it has field errors, nil otherwise.
+func (err *ValidationError) Err() error {
+	if len(err.errors) == 0 {
+		return nil
+	}
+	return err
+}
+
+// Message returns the error description, without field errors.
+func (err *ValidationError) Message() string {
+	return fmt.Sprintf("invalid input, %d field error(s) found", len(err.errors))
+}
+
+// Error returns the error description with field errors.
+func (err *ValidationError) Error() string {
+	message := err.Message() + formatPosition(err.file, err.function, err.line)
+	for _, fieldError := range err.errors {
+		message += "\n  - " + strings.ReplaceAll(fieldError.Error(), "\n", "\n    ")
+	}
+	return message
+}
+
+// Unwrap method returns the field errors. Multiple errors wrapper method.
+func (err *ValidationError) Unwrap() []error {
+	causes := make([]error, 0, len(err.errors))
+	for _, fieldError := range err.errors {
+		causes = append(causes, fieldError)
+	}
+	return causes
+}
+
+// File method returns error filename.
+func (err *ValidationError) File() string {
+	return err.file
+}
+
+// Function method returns function of error in file.
+func (err *ValidationError) Function() string {
+	return err.function
+}
+
+// Line method returns line number of error in file.
+func (err *ValidationError) Line() int {
+	return err.line
+}
+
+// Cause method returns nil: field errors are returned by Unwrap method.
+func (err *ValidationError) Cause() error {
+	return nil
+}
+
+// StackTrace method returns the call stack of validation creation.
+func (err *ValidationError) StackTrace() *StackTrace {
+	return err.stack
+}
+
+// Code method returns an empty code: codes are defined by field errors.
+func (err *ValidationError) Code() Code {
+	return ""
+}
+
+// Kind method returns KindInvalidInput.
+func (err *ValidationError) Kind() Kind {
+	return KindInvalidInput
+}
+
+// Attributes method returns the attributes of field errors (see AttributesOf function).
+func (err *ValidationError) Attributes() []Attribute {
+	return AttributesOf(err)
+}
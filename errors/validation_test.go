@@ -0,0 +1,130 @@
+package errors
+
+import (
+	"encoding/json"
+	goerr "errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// validateAddress is a nested validator.
+func validateAddress(zip string) error {
+	validation := NewValidationError()
+	if zip == "" {
+		validation.Add("zip", "REQUIRED", "zip code is required")
+	}
+	return validation.Err()
+}
+
+func TestValidationError_Err(t *testing.T) {
+	validation := NewValidationError()
+	if err := validation.Err(); err != nil {
+		t.Errorf("Err() = '%v', want nil", err)
+	}
+	validation.Add("name", "REQUIRED", "name is required")
+	if err := validation.Err(); err != validation {
+		t.Errorf("Err() = '%v', want validation error", err)
+	}
+}
+
+func TestValidationError_Paths(t *testing.T) {
+	foreign := fmt.Errorf("must be positive")
+	validation := NewValidationError()
+	validation.Add("name", "REQUIRED", "name is required")
+	validation.AddError("address", validateAddress(""))
+	validation.AddError("items", validateItem(3, foreign))
+	validation.AddError("email", nil)
+	validation.AddError("age", foreign)
+
+	tests := []struct {
+		wantPath    string
+		wantCode    Code
+		wantMessage string
+	}{
+		{wantPath: "name", wantCode: "REQUIRED", wantMessage: "name: name is required"},
+		{wantPath: "address.zip", wantCode: "REQUIRED", wantMessage: "address.zip: zip code is required"},
+		{wantPath: "items[3].qty", wantCode: "", wantMessage: "items[3].qty: must be positive"},
+		{wantPath: "age", wantCode: "", wantMessage: "age: must be positive"},
+	}
+	fieldErrors := validation.Errors()
+	if len(fieldErrors) != len(tests) {
+		t.Fatalf("Errors() has %d errors, want %d", len(fieldErrors), len(tests))
+	}
+	for idx, tt := range tests {
+		t.Run(tt.wantPath, func(t *testing.T) {
+			fieldError := fieldErrors[idx]
+			if fieldError.Path() != tt.wantPath {
+				t.Errorf("Path() = '%s', want '%s'", fieldError.Path(), tt.wantPath)
+			}
+			if fieldError.Code() != tt.wantCode {
+				t.Errorf("Code() = '%s', want '%s'", fieldError.Code(), tt.wantCode)
+			}
+			if fieldError.Message() != tt.wantMessage {
+				t.Errorf("Message() = '%s', want '%s'", fieldError.Message(), tt.wantMessage)
+			}
+			if got := fmt.Sprintf("%v", fieldError); got != tt.wantMessage {
+				t.Errorf("%%v = '%s', want '%s'", got, tt.wantMessage)
+			}
+		})
+	}
+}
+
+// validateItem is a nested validator of an indexed item.
+func validateItem(index int, err error) error {
+	validation := NewValidationError()
+	validation.AddError(fmt.Sprintf("[%d].qty", index), err)
+	return validation.Err()
+}
+
+func TestValidationError_Chain(t *testing.T) {
+	foreign := fmt.Errorf("must be positive")
+	validation := NewValidationError()
+	validation.Add("name", codeUserNotFound, "name is required")
+	validation.AddError("qty", foreign)
+	err := validation.Err()
+
+	if !goerr.Is(err, foreign) {
+		t.Errorf("errors.Is() = false, want true for field error cause")
+	}
+	if !goerr.Is(err, codeUserNotFound) {
+		t.Errorf("errors.Is() = false, want true for field error code")
+	}
+	var fieldError *FieldError
+	if !goerr.As(err, &fieldError) || fieldError.Path() != "name" {
+		t.Errorf("errors.As() did not find field error 'name'")
+	}
+	if got := KindOf(err); got != KindInvalidInput {
+		t.Errorf("KindOf() = '%s', want '%s'", got, KindInvalidInput)
+	}
+	message := err.Error()
+	if !strings.HasPrefix(message, "invalid input, 2 field error(s) found") ||
+		!strings.Contains(message, "\n  - name: name is required") ||
+		!strings.Contains(message, "\n  - qty: must be positive") {
+		t.Errorf("Error() = '%s', want field errors list", message)
+	}
+}
+
+func TestValidationError_JSON(t *testing.T) {
+	validation := NewValidationError()
+	validation.Add("address.zip", "REQUIRED", "zip code is required")
+	data, err := json.Marshal(validation)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"field":"address.zip"`) || !strings.Contains(string(data), `"kind":"invalid_input"`) {
+		t.Errorf("json.Marshal() = '%s', want field path and kind", data)
+	}
+	var decoded error
+	if err := FromJSON(data, &decoded); err != nil {
+		t.Fatalf("FromJSON() error = %v", err)
+	}
+	decodedValidation, ok := decoded.(*ValidationError)
+	if !ok || len(decodedValidation.Errors()) != 1 || decodedValidation.Errors()[0].Path() != "address.zip" ||
+		decodedValidation.Errors()[0].Code() != "REQUIRED" || decodedValidation.Line() != validation.Line() {
+		t.Errorf("FromJSON() = '%v', want validation error with field error 'address.zip'", decoded)
+	}
+	if decoded.Error() != validation.Error() {
+		t.Errorf("FromJSON() = '%v', want '%v'", decoded.Error(), validation.Error())
+	}
+}
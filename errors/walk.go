@@ -0,0 +1,31 @@
+package errors
+
+// Walk visits the error chain depth first, from the error to the root cause, until visit function returns true.
+// Aggregated errors (`Unwrap() []error` method) are visited in aggregation order, with their own causes.
+// Function returns true if visit function returned true for an error of the chain:
+//
+//	found := errors.Walk(err, func(member error) bool {
+//		coder, ok := member.(errors.Coder)
+//		return ok && coder.Code() == "USR-404"
+//	})
+func Walk(err error, visit func(err error) bool) bool {
+	if err == nil {
+		return false
+	}
+	if visit(err) {
+		return true
+	}
+	switch wrapper := err.(type) {
+	case interface{ Unwrap() []error }:
+		for _, member := range wrapper.Unwrap() {
+			if Walk(member, visit) {
+				return true
+			}
+		}
+		return false
+	case interface{ Unwrap() error }:
+		return Walk(wrapper.Unwrap(), visit)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,31 @@
+package errors
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestWalk(t *testing.T) {
+	root := fmt.Errorf("connection refused")
+	err := Append(NewWithCause(root, "query failed"), fmt.Errorf("cache miss: %w", New("key not found")))
+	visited := make([]string, 0)
+	found := Walk(err, func(member error) bool {
+		visited = append(visited, fmt.Sprintf("%T", member))
+		return false
+	})
+	want := []string{"*errors.MultiError", "*errors.customError", "*errors.errorString", "*fmt.wrapError", "*errors.customError"}
+	if found || !reflect.DeepEqual(visited, want) {
+		t.Errorf("Walk() = %v, visited = %v, want false and %v", found, visited, want)
+	}
+	visited = visited[:0]
+	if !Walk(err, func(member error) bool {
+		visited = append(visited, fmt.Sprintf("%T", member))
+		return member == root
+	}) || len(visited) != 3 {
+		t.Errorf("Walk() visited = %v, want stop at root cause", visited)
+	}
+	if Walk(nil, func(error) bool { return true }) {
+		t.Errorf("Walk(nil) = true, want false")
+	}
+}
@@ -1,3 +1,3 @@
 module github.com/deverdeb/bvmgo-util
 
-go 1.19
+go 1.20
@@ -3,6 +3,7 @@ package introsp
 import (
 	"github.com/deverdeb/bvmgo-util/errors"
 	"reflect"
+	"strings"
 )
 
 // Set method assigns the pointer to the value.
@@ -37,6 +38,46 @@ func SetAttribute(structPointer interface{}, attribute string, value interface{}
 	return nil
 }
 
+// SetAttributePath method assigns the structure attribute identified by a path to the value.
+// Path is a list of attribute names separated by dots (example: "BaseService.Logger").
+// Intermediate attributes must be structures or not nil pointers of structures.
+func SetAttributePath(structPointer interface{}, path string, value interface{}) error {
+	ptrValue := reflect.ValueOf(structPointer)
+	if ptrValue.Kind() != reflect.Ptr || ptrValue.Elem().Kind() != reflect.Struct {
+		return errors.New("failed to set attribute '%s', require a pointer of structure, unsupported type %s",
+			path, TypeName(reflect.TypeOf(structPointer)))
+	}
+	attributeValue := ptrValue.Elem()
+	parentPath := ""
+	for _, attribute := range strings.Split(path, ".") {
+		if attributeValue.Kind() == reflect.Ptr {
+			if attributeValue.IsNil() {
+				return errors.New("failed to set attribute '%s', attribute '%s' is a nil pointer",
+					path, parentPath)
+			}
+			attributeValue = attributeValue.Elem()
+		}
+		if attributeValue.Kind() != reflect.Struct {
+			return errors.New("failed to set attribute '%s', require a structure to access '%s', unsupported type %s",
+				path, attribute, TypeName(attributeValue.Type()))
+		}
+		structType := attributeValue.Type()
+		attributeValue = attributeValue.FieldByName(attribute)
+		if !attributeValue.IsValid() {
+			return errors.New("failed to set attribute '%s', attribute '%s' is not found on structure %s",
+				path, attribute, TypeName(structType))
+		}
+		if parentPath != "" {
+			parentPath += "."
+		}
+		parentPath += attribute
+	}
+	if err := SetReflectValue(attributeValue, value); err != nil {
+		return errors.NewWithCause(err, "failed to set attribute '%s', error found", path)
+	}
+	return nil
+}
+
 // SetReflectValue method assigns the reflect.Value to the value.
 func SetReflectValue(elementValue reflect.Value, value interface{}) error {
 	if value == nil {
@@ -302,3 +302,60 @@ func TestSetAttribute_errors(t *testing.T) {
 		})
 	}
 }
+
+func TestSetAttributePath(t *testing.T) {
+	type Nested struct {
+		Field int
+	}
+	type Embedded struct {
+		Nested
+		PtrNested *Nested
+	}
+	type TestSetStruct struct {
+		Embedded
+		Value Nested
+	}
+	testObj := TestSetStruct{Embedded: Embedded{PtrNested: &Nested{}}}
+	if err := SetAttributePath(&testObj, "Value.Field", 12); err != nil {
+		t.Errorf("SetAttributePath() error = %v, want no Error", err)
+	}
+	if err := SetAttributePath(&testObj, "Embedded.Nested.Field", 34); err != nil {
+		t.Errorf("SetAttributePath() error = %v, want no Error", err)
+	}
+	if err := SetAttributePath(&testObj, "Embedded.PtrNested.Field", 56); err != nil {
+		t.Errorf("SetAttributePath() error = %v, want no Error", err)
+	}
+	if testObj.Value.Field != 12 || testObj.Nested.Field != 34 || testObj.PtrNested.Field != 56 {
+		t.Errorf("SetAttributePath() variable = %v, want = %v", testObj, "12, 34 and 56 values")
+	}
+}
+
+func TestSetAttributePath_errors(t *testing.T) {
+	type Nested struct {
+		Field int
+	}
+	type TestSetStruct struct {
+		PtrNested *Nested
+		Value     int
+	}
+	testObj := TestSetStruct{}
+	tests := []struct {
+		name      string
+		structure interface{}
+		path      string
+		want      string
+	}{
+		{name: "not a pointer", structure: testObj, path: "Value", want: "require a pointer of structure"},
+		{name: "nil pointer", structure: &testObj, path: "PtrNested.Field", want: "attribute 'PtrNested' is a nil pointer"},
+		{name: "not a structure", structure: &testObj, path: "Value.Field", want: "require a structure to access 'Field'"},
+		{name: "unknown attribute", structure: &testObj, path: "Unknown", want: "attribute 'Unknown' is not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := SetAttributePath(tt.structure, tt.path, 1)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("SetAttributePath() = \"%v\", want error contains \"%v\"", err, tt.want)
+			}
+		})
+	}
+}
@@ -22,36 +22,56 @@ func DefaultLogger() *Logger {
 	return defaultLogger
 }
 
+// Flush writes buffered logs of the default logger output (see Logger.Flush).
+func Flush() error {
+	return defaultLogger.Flush()
+}
+
 func Trace(attributes ...any) {
 	defaultLogger.log(LevelTrace, attributes...)
 }
 func Tracef(format string, attributes ...any) {
 	defaultLogger.logf(LevelTrace, format, attributes...)
 }
+func Tracew(message string, keysAndValues ...any) {
+	defaultLogger.logw(LevelTrace, message, keysAndValues...)
+}
 func Debug(attributes ...any) {
 	defaultLogger.log(LevelDebug, attributes...)
 }
 func Debugf(format string, attributes ...any) {
 	defaultLogger.logf(LevelDebug, format, attributes...)
 }
+func Debugw(message string, keysAndValues ...any) {
+	defaultLogger.logw(LevelDebug, message, keysAndValues...)
+}
 func Info(attributes ...any) {
 	defaultLogger.log(LevelInfo, attributes...)
 }
 func Infof(format string, attributes ...any) {
 	defaultLogger.logf(LevelInfo, format, attributes...)
 }
+func Infow(message string, keysAndValues ...any) {
+	defaultLogger.logw(LevelInfo, message, keysAndValues...)
+}
 func Warn(attributes ...any) {
 	defaultLogger.log(LevelWarn, attributes...)
 }
 func Warnf(format string, attributes ...any) {
 	defaultLogger.logf(LevelWarn, format, attributes...)
 }
+func Warnw(message string, keysAndValues ...any) {
+	defaultLogger.logw(LevelWarn, message, keysAndValues...)
+}
 func Error(attributes ...any) {
 	defaultLogger.log(LevelError, attributes...)
 }
 func Errorf(format string, attributes ...any) {
 	defaultLogger.logf(LevelError, format, attributes...)
 }
+func Errorw(message string, keysAndValues ...any) {
+	defaultLogger.logw(LevelError, message, keysAndValues...)
+}
 func Fatal(attributes ...any) {
 	defaultLogger.log(LevelFatal, attributes...)
 	exit(1)
@@ -60,3 +80,12 @@ func Fatalf(format string, attributes ...any) {
 	defaultLogger.logf(LevelFatal, format, attributes...)
 	exit(1)
 }
+func Fatalw(message string, keysAndValues ...any) {
+	defaultLogger.logw(LevelFatal, message, keysAndValues...)
+	exit(1)
+}
+
+// With returns a child logger of the default logger with bound fields (see Logger.With).
+func With(keysAndValues ...any) *Logger {
+	return defaultLogger.With(keysAndValues...)
+}
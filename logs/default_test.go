@@ -150,10 +150,10 @@ func ExampleLogger_Formatter() {
 
 	// Output:
 	// 1982-03-15T12:56:14 [ INFO] test error ( default_test.go:148 )
-	//   > error: second error ( default_test.go:144 )
+	//   > error: second error ( default_test.go:145 )
 	//   > cause by: first error
 	// 1982-03-15T12:56:14 [ WARN] test error ( default_test.go:149 )
-	//   > error: second error ( default_test.go:144 )
+	//   > error: second error ( default_test.go:145 )
 	//   > cause by: first error
 }
 
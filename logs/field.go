@@ -0,0 +1,34 @@
+package logs
+
+import (
+	"fmt"
+)
+
+// badKey is the key of field values without key (odd number of keys and values).
+const badKey = "!BADKEY"
+
+// Field is a structured key/value information of a log message (example: the request identifier).
+type Field struct {
+	// Key is the field name.
+	Key string
+	// Value is the field value.
+	Value any
+}
+
+// fieldsOf converts alternated keys and values to fields ("requestId", id, "user", u).
+// Keys which are not strings are converted with fmt.Sprint. A value without key has "!BADKEY" key.
+func fieldsOf(keysAndValues []any) []Field {
+	fields := make([]Field, 0, (len(keysAndValues)+1)/2)
+	for idx := 0; idx < len(keysAndValues); idx += 2 {
+		if idx+1 == len(keysAndValues) {
+			fields = append(fields, Field{Key: badKey, Value: keysAndValues[idx]})
+			break
+		}
+		key, ok := keysAndValues[idx].(string)
+		if !ok {
+			key = fmt.Sprint(keysAndValues[idx])
+		}
+		fields = append(fields, Field{Key: key, Value: keysAndValues[idx+1]})
+	}
+	return fields
+}
@@ -0,0 +1,51 @@
+package logs
+
+import (
+	"fmt"
+	"github.com/deverdeb/bvmgo-util/errors"
+	"reflect"
+	"testing"
+)
+
+func TestFieldsOf(t *testing.T) {
+	tests := []struct {
+		name          string
+		keysAndValues []any
+		want          []Field
+	}{
+		{name: "no field", keysAndValues: nil, want: []Field{}},
+		{name: "key/value pairs", keysAndValues: []any{"requestId", 42, "user", "bob"},
+			want: []Field{{Key: "requestId", Value: 42}, {Key: "user", Value: "bob"}}},
+		{name: "not string key", keysAndValues: []any{12, true}, want: []Field{{Key: "12", Value: true}}},
+		{name: "value without key", keysAndValues: []any{"user", "bob", 42},
+			want: []Field{{Key: "user", Value: "bob"}, {Key: badKey, Value: 42}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fieldsOf(tt.keysAndValues); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("fieldsOf() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func ExampleLogger_With() {
+	mockBeforeTest()
+	defer restoreAfterTest()
+	logger := New("ExampleLoggerWith")
+	requestLogger := logger.With("requestId", 42, "user", "bob smith")
+
+	requestLogger.Info("request received")
+	requestLogger.Warnw("slow request", "duration", "2s")
+	requestLogger.Errorw("request failed", "status", 500, errors.New("database unavailable"))
+	logger.Infow("no bound field", "user", "alice")
+	fmt.Println(len(logger.Fields()), len(requestLogger.Fields()))
+
+	// Output:
+	// 1982-03-15T12:56:14 [ INFO] ExampleLoggerWith - request received ( field_test.go:38 ) requestId=42 user="bob smith"
+	// 1982-03-15T12:56:14 [ WARN] ExampleLoggerWith - slow request ( field_test.go:39 ) requestId=42 user="bob smith" duration=2s
+	// 1982-03-15T12:56:14 [ERROR] ExampleLoggerWith - request failed ( field_test.go:40 ) requestId=42 user="bob smith" status=500
+	//   > error: database unavailable ( field_test.go:40 )
+	// 1982-03-15T12:56:14 [ INFO] ExampleLoggerWith - no bound field ( field_test.go:41 ) user=alice
+	// 0 2
+}
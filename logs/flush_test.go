@@ -0,0 +1,20 @@
+package logs
+
+import (
+	"bufio"
+	"os"
+)
+
+func ExampleLogger_Flush() {
+	mockBeforeTest()
+	defer restoreAfterTest()
+	logger := New("ExampleLoggerFlush")
+	buffer := bufio.NewWriter(os.Stdout)
+	logger.Output().SetOutput(buffer)
+
+	logger.Info("buffered log")
+	_ = logger.Flush()
+
+	// Output:
+	// 1982-03-15T12:56:14 [ INFO] ExampleLoggerFlush - buffered log ( flush_test.go:15 )
+}
@@ -4,15 +4,60 @@ import (
 	goerr "errors"
 	"fmt"
 	"github.com/deverdeb/bvmgo-util/errors"
-	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 )
 
+// sensitiveValues is true if built-in formatters write the values of sensitive error attributes
+// (see EnableSensitiveValues).
+var sensitiveValues atomic.Bool
+
+// EnableSensitiveValues enables or disables the values of sensitive error attributes (see errors.WithSensitive)
+// in built-in formatters. Values are disabled by default: they are replaced by errors.RedactedValue.
+func EnableSensitiveValues(enabled bool) {
+	sensitiveValues.Store(enabled)
+}
+
+// IsSensitiveValuesEnabled returns true if built-in formatters write the values of sensitive error attributes.
+func IsSensitiveValuesEnabled() bool {
+	return sensitiveValues.Load()
+}
+
+// errorAttributes returns the attributes of the error chain, with redacted sensitive values
+// if sensitive values are disabled (see EnableSensitiveValues).
+func errorAttributes(err error) []errors.Attribute {
+	if IsSensitiveValuesEnabled() {
+		return errors.AttributesOf(err)
+	}
+	return errors.SafeAttributes(err)
+}
+
 // Formatter is an interface to format log messages.
 type Formatter interface {
 	// Format return a formatted log message
-	Format(prefix string, level LogLevel, fileName string, fileLine int,
-		date time.Time, err error, message string) string
+	Format(entry *Entry) string
+}
+
+// Entry is a log message with its context.
+type Entry struct {
+	// Prefix is the logger prefix.
+	Prefix string
+	// Level is the message level.
+	Level LogLevel
+	// File is the source file of the log call.
+	File string
+	// Line is the line of the log call in source file.
+	Line int
+	// Time is the log date.
+	Time time.Time
+	// Error is the logged error. Nil if no error is logged.
+	Error error
+	// Message is the log message.
+	Message string
+	// Fields contains the structured fields: the logger fields (see Logger.With), then the log call fields.
+	Fields []Field
 }
 
 // defaultFormaterImpl is a basic Formatter implementation.
@@ -20,24 +65,33 @@ type defaultFormaterImpl struct {
 }
 
 // Format return a formatted log message
-func (formatter *defaultFormaterImpl) Format(prefix string, level LogLevel, fileName string, fileLine int,
-	date time.Time, err error, message string) string {
-	strDate := date.Format("2006-01-02T15:04:05")
-	result := fmt.Sprintf("%s [%5s] ", strDate, level.String())
-	if prefix != "" {
-		result += prefix + " - "
+func (formatter *defaultFormaterImpl) Format(entry *Entry) string {
+	strDate := entry.Time.Format("2006-01-02T15:04:05")
+	result := fmt.Sprintf("%s [%5s] ", strDate, entry.Level.String())
+	if entry.Prefix != "" {
+		result += entry.Prefix + " - "
+	}
+	result += entry.Message
+	if entry.Line > 0 {
+		result += fmt.Sprintf(" ( %s )", errors.FormatPosition(entry.File, "", entry.Line, errors.PositionFileName))
+	}
+	if len(entry.Fields) > 0 {
+		result += " " + FormatFields(entry.Fields)
 	}
-	result += message
-	if fileLine > 0 {
-		result += fmt.Sprintf(" ( %s:%d )", filepath.Base(fileName), fileLine)
+	if attributes := errorAttributes(entry.Error); len(attributes) > 0 {
+		result += " " + FormatAttributes(attributes)
 	}
-	if err != nil {
-		result += "\n  > error: " + FormatError(err, -1)
+	if entry.Error != nil {
+		result += "\n  > error: " + FormatError(entry.Error, -1)
 	}
 	return result
 }
 
 // FormatError converts an error to log message
+//
+// Errors with multiple causes (`Unwrap() []error` method) are formatted with all causes.
+// Codes of traceable errors are formatted between brackets (example: "user not found [USER_NOT_FOUND] ( user.go:42 )").
+// Stack traces of traceable errors are formatted when captured (see errors.EnableStackTrace).
 func FormatError(err error, errorsDepth int) string {
 	if err == nil {
 		return "nil"
@@ -48,7 +102,22 @@ func FormatError(err error, errorsDepth int) string {
 	var result string
 	traceableError, ok := err.(errors.TraceableError)
 	if ok {
-		result = fmt.Sprintf("%s ( %s:%d )", traceableError.Message(), filepath.Base(traceableError.File()), traceableError.Line())
+		result = traceableError.Message()
+		if coded, ok := err.(errors.Coder); ok && coded.Code() != "" {
+			result += fmt.Sprintf(" [%s]", coded.Code())
+		}
+		result += fmt.Sprintf(" ( %s )", errors.FormatPosition(traceableError.File(), traceableError.Function(),
+			traceableError.Line(), errors.PositionFileName))
+		if stackTracer, ok := err.(errors.StackTracer); ok {
+			for _, frame := range stackTracer.StackTrace().Frames() {
+				result += fmt.Sprintf("\n      at %s ( %s:%d )", frame.Function,
+					errors.FormatPath(frame.File, errors.PositionFileName), frame.Line)
+			}
+		}
+	} else if multiError, ok := err.(multipleCausesError); ok {
+		result = formatMultipleCausesError(multiError, errorsDepth)
+	} else if messageError, ok := err.(messageError); ok {
+		result = messageError.Message()
 	} else {
 		result = err.Error()
 	}
@@ -58,3 +127,74 @@ func FormatError(err error, errorsDepth int) string {
 	}
 	return result
 }
+
+// messageError is an error with a message method, which returns the error message without causes.
+type messageError interface {
+	error
+	// Message method returns error message.
+	Message() string
+}
+
+// positionError is an error with position (example: errors.MultiError).
+type positionError interface {
+	error
+	// File method returns error filename.
+	File() string
+	// Line method returns line number of error in file.
+	Line() int
+}
+
+// multipleCausesError is an error with multiple causes.
+type multipleCausesError interface {
+	error
+	// Unwrap method returns the causes.
+	Unwrap() []error
+}
+
+// formatMultipleCausesError converts an error with multiple causes to log message.
+// Causes are indented.
+func formatMultipleCausesError(err multipleCausesError, errorsDepth int) string {
+	var result string
+	if messageError, ok := err.(messageError); ok {
+		result = messageError.Message()
+	} else {
+		result, _, _ = strings.Cut(err.Error(), "\n")
+	}
+	if positionError, ok := err.(positionError); ok && positionError.Line() > 0 {
+		result += fmt.Sprintf(" ( %s )", errors.FormatPosition(positionError.File(), "",
+			positionError.Line(), errors.PositionFileName))
+	}
+	for _, cause := range err.Unwrap() {
+		result += "\n  - " + strings.ReplaceAll(FormatError(cause, errorsDepth-1), "\n", "\n    ")
+	}
+	return result
+}
+
+// FormatAttributes converts error attributes to structured fields: `key=value` separated by spaces.
+// Values containing spaces, quotes or equal signs are quoted (example: `userId=42 path="/tmp/my file"`).
+func FormatAttributes(attributes []errors.Attribute) string {
+	fields := make([]string, 0, len(attributes))
+	for _, attribute := range attributes {
+		fields = append(fields, formatKeyValue(attribute.Key, attribute.Value))
+	}
+	return strings.Join(fields, " ")
+}
+
+// FormatFields converts log fields to structured fields: `key=value` separated by spaces (see FormatAttributes).
+func FormatFields(fields []Field) string {
+	formatted := make([]string, 0, len(fields))
+	for _, field := range fields {
+		formatted = append(formatted, formatKeyValue(field.Key, field.Value))
+	}
+	return strings.Join(formatted, " ")
+}
+
+// formatKeyValue converts a key and its value to a structured field `key=value`.
+// Values containing spaces, quotes or equal signs are quoted.
+func formatKeyValue(key string, value any) string {
+	formatted := fmt.Sprint(value)
+	if formatted == "" || strings.ContainsAny(formatted, " \t\n\"=") {
+		formatted = strconv.Quote(formatted)
+	}
+	return key + "=" + formatted
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/deverdeb/bvmgo-util/errors"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestFormatError(t *testing.T) {
@@ -43,3 +44,100 @@ func TestFormatError_WithCause(t *testing.T) {
 		t.Errorf("FormatError() = '%v', want '%v'", result4, "...")
 	}
 }
+
+type multipleCausesTestError struct {
+	causes []error
+}
+
+func (err *multipleCausesTestError) Error() string {
+	return "multiple errors\nfirst line only"
+}
+
+func (err *multipleCausesTestError) Unwrap() []error {
+	return err.causes
+}
+
+func TestFormatError_WithMultipleCauses(t *testing.T) {
+	err := &multipleCausesTestError{causes: []error{
+		fmt.Errorf("first error"),
+		errors.NewWithCause(fmt.Errorf("cause error"), "second error"),
+	}}
+	result := FormatError(err, 5)
+	for _, want := range []string{"multiple errors\n  - first error\n  - second error ( ", " )\n      > cause by: cause error"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("FormatError() = '%v', want contains '%v'", result, want)
+		}
+	}
+	if strings.Contains(result, "first line only") {
+		t.Errorf("FormatError() = '%v', want does not contain '%v'", result, "first line only")
+	}
+}
+
+func TestFormatError_WithStackTrace(t *testing.T) {
+	err := errors.WithStack(errors.New("stack error"))
+	result := FormatError(err, 5)
+	want := "\n      at github.com/deverdeb/bvmgo-util/logs.TestFormatError_WithStackTrace ( formatter_test.go:"
+	if !strings.Contains(result, want) {
+		t.Errorf("FormatError() = '%v', want contains '%v'", result, want)
+	}
+}
+
+func TestFormatError_WithCode(t *testing.T) {
+	err := errors.WithCode(errors.New("user not found"), "USER_NOT_FOUND")
+	result := FormatError(err, 5)
+	if !strings.HasPrefix(result, "user not found [USER_NOT_FOUND] ( ") {
+		t.Errorf("FormatError() = '%v', want prefix '%v'", result, "user not found [USER_NOT_FOUND] ( ")
+	}
+}
+
+func TestFormatAttributes(t *testing.T) {
+	attributes := []errors.Attribute{{Key: "userId", Value: 42}, {Key: "path", Value: "/tmp/my file"}, {Key: "empty", Value: ""}}
+	want := `userId=42 path="/tmp/my file" empty=""`
+	if result := FormatAttributes(attributes); result != want {
+		t.Errorf("FormatAttributes() = '%v', want '%v'", result, want)
+	}
+}
+
+func TestDefaultFormatter_WithAttributes(t *testing.T) {
+	err := errors.With(errors.New("user not found"), "userId", 42)
+	result := (&defaultFormaterImpl{}).Format(&Entry{Level: LevelError, File: "service.go", Line: 12, Time: time.Now(),
+		Error: err, Message: "failed to load user"})
+	want := " failed to load user ( service.go:12 ) userId=42\n  > error: user not found ( "
+	if !strings.Contains(result, want) {
+		t.Errorf("Format() = '%v', want contains '%v'", result, want)
+	}
+}
+
+func TestFormatError_WithMultiError(t *testing.T) {
+	err := errors.Append(fmt.Errorf("first error"), errors.New("second error"))
+	result := FormatError(err, 5)
+	for _, want := range []string{"2 error(s) occurred ( formatter_test.go:", "\n  - first error\n  - second error ( "} {
+		if !strings.Contains(result, want) {
+			t.Errorf("FormatError() = '%v', want contains '%v'", result, want)
+		}
+	}
+}
+
+func TestFormatError_WithPositionStyle(t *testing.T) {
+	defer errors.ResetPositionConfiguration()
+	errors.SetPositionStyle(errors.PositionFunction)
+	result := FormatError(errors.New("styled error"), 5)
+	want := "styled error ( github.com/deverdeb/bvmgo-util/logs.TestFormatError_WithPositionStyle:"
+	if !strings.HasPrefix(result, want) {
+		t.Errorf("FormatError() = '%v', want prefix '%v'", result, want)
+	}
+}
+
+func TestDefaultFormatter_WithSensitiveAttributes(t *testing.T) {
+	err := errors.WithSensitive(errors.New("authentication failed"), "token", "SECRET")
+	entry := &Entry{Level: LevelError, File: "service.go", Line: 12, Time: time.Now(), Error: err, Message: "failed"}
+	if result := (&defaultFormaterImpl{}).Format(entry); strings.Contains(result, "SECRET") ||
+		!strings.Contains(result, "token="+errors.RedactedValue) {
+		t.Errorf("Format() = '%v', want redacted sensitive attribute", result)
+	}
+	EnableSensitiveValues(true)
+	defer EnableSensitiveValues(false)
+	if result := (&defaultFormaterImpl{}).Format(entry); !strings.Contains(result, "token=SECRET") {
+		t.Errorf("Format() = '%v', want sensitive attribute value", result)
+	}
+}
@@ -0,0 +1,212 @@
+package logs
+
+import (
+	"bytes"
+	"encoding/json"
+	goerr "errors"
+	"fmt"
+	"github.com/deverdeb/bvmgo-util/errors"
+	"time"
+)
+
+// JSONFieldNames contains the names of JSON log entries members. Empty names are not written.
+type JSONFieldNames struct {
+	// Time is the name of log date member (RFC 3339 with nanoseconds).
+	Time string
+	// Level is the name of log level member.
+	Level string
+	// Prefix is the name of logger prefix member. Member is not written if logger has no prefix.
+	Prefix string
+	// Message is the name of log message member.
+	Message string
+	// File is the name of log call source file member.
+	File string
+	// Line is the name of log call line member.
+	Line string
+	// Errors is the name of the error chain member (array of errors).
+	Errors string
+	// Fields is the name of the structured fields member (object with log fields and errors attributes).
+	Fields string
+}
+
+// DefaultJSONFieldNames returns the default names of JSON log entries members:
+// "time", "level", "logger", "message", "file", "line", "errors" and "fields".
+func DefaultJSONFieldNames() JSONFieldNames {
+	return JSONFieldNames{
+		Time:    "time",
+		Level:   "level",
+		Prefix:  "logger",
+		Message: "message",
+		File:    "file",
+		Line:    "line",
+		Errors:  "errors",
+		Fields:  "fields",
+	}
+}
+
+// JSONFormatter is a Formatter implementation which writes a JSON object by log entry, on one line.
+//
+// Example:
+//
+//	{"time":"2023-03-15T12:56:14.000000123Z","level":"ERROR","logger":"users","message":"failed to load user",
+//	"file":"user.go","line":42,"errors":[{"message":"user not found","code":"USR-404","file":"user.go","line":12,
+//	"function":"github.com/me/project/users.Load"}],"fields":{"requestId":"abc","userId":42}}
+type JSONFormatter struct {
+	// Names contains the names of JSON members.
+	Names JSONFieldNames
+}
+
+// NewJSONFormatter creates a JSON formatter with the default members names (see DefaultJSONFieldNames).
+func NewJSONFormatter() *JSONFormatter {
+	return &JSONFormatter{Names: DefaultJSONFieldNames()}
+}
+
+// jsonErrorEntry is the JSON representation of an error of the logged error chain.
+type jsonErrorEntry struct {
+	// Message is the error message, without causes messages if error has a `Message() string` method.
+	Message string `json:"message"`
+	// Code is the error code of traceable errors.
+	Code errors.Code `json:"code,omitempty"`
+	// Kind is the error kind of traceable errors.
+	Kind errors.Kind `json:"kind,omitempty"`
+	// File is the error source file of traceable errors.
+	File string `json:"file,omitempty"`
+	// Line is the error line of traceable errors.
+	Line int `json:"line,omitempty"`
+	// Function is the error function of traceable errors.
+	Function string `json:"function,omitempty"`
+}
+
+// Format return the log entry as a JSON object, without line feed.
+// Strings are escaped (line feeds...) and invalid UTF-8 sequences are replaced by the Unicode replacement character.
+func (formatter *JSONFormatter) Format(entry *Entry) string {
+	buffer := &bytes.Buffer{}
+	buffer.WriteByte('{')
+	separator := false
+	writeMember := func(name string, value any) {
+		if name == "" {
+			return
+		}
+		if separator {
+			buffer.WriteByte(',')
+		}
+		separator = true
+		buffer.Write(marshalJSON(name))
+		buffer.WriteByte(':')
+		buffer.Write(marshalJSON(value))
+	}
+	names := formatter.Names
+	writeMember(names.Time, entry.Time.Format(time.RFC3339Nano))
+	writeMember(names.Level, entry.Level.String())
+	if entry.Prefix != "" {
+		writeMember(names.Prefix, entry.Prefix)
+	}
+	writeMember(names.Message, entry.Message)
+	if entry.Line > 0 {
+		writeMember(names.File, errors.FormatPath(entry.File, errors.PositionFileName))
+		writeMember(names.Line, entry.Line)
+	}
+	if entry.Error != nil {
+		writeMember(names.Errors, jsonErrorEntries(entry.Error, make([]*jsonErrorEntry, 0)))
+	}
+	if fields := formatter.jsonFields(entry); len(fields) > 0 {
+		writeMember(names.Fields, fields)
+	}
+	buffer.WriteByte('}')
+	return buffer.String()
+}
+
+// jsonFields returns the log fields followed by the logged error attributes, as ordered JSON object without
+// duplicated keys: the last value of a log field is kept at the position of its first occurrence,
+// and error attributes are ignored if a log field has the same key.
+// Sensitive error attributes are redacted unless sensitive values are enabled (see EnableSensitiveValues).
+func (formatter *JSONFormatter) jsonFields(entry *Entry) jsonObject {
+	fields := make(jsonObject, 0, len(entry.Fields))
+	indexByKey := make(map[string]int, len(entry.Fields))
+	for _, field := range entry.Fields {
+		if index, found := indexByKey[field.Key]; found {
+			fields[index].Value = field.Value
+			continue
+		}
+		indexByKey[field.Key] = len(fields)
+		fields = append(fields, field)
+	}
+	for _, attribute := range errorAttributes(entry.Error) {
+		if _, found := indexByKey[attribute.Key]; !found {
+			indexByKey[attribute.Key] = len(fields)
+			fields = append(fields, Field{Key: attribute.Key, Value: attribute.Value})
+		}
+	}
+	return fields
+}
+
+// jsonErrorEntries appends the errors of the chain to entries, from the error to the root cause.
+// Aggregated errors (`Unwrap() []error` method) are appended after the aggregating error.
+func jsonErrorEntries(err error, entries []*jsonErrorEntry) []*jsonErrorEntry {
+	for ; err != nil; err = goerr.Unwrap(err) {
+		errorEntry := &jsonErrorEntry{}
+		if messageError, ok := err.(messageError); ok {
+			errorEntry.Message = messageError.Message()
+		} else {
+			errorEntry.Message = err.Error()
+		}
+		if coded, ok := err.(errors.Coder); ok {
+			errorEntry.Code = coded.Code()
+		}
+		if kinded, ok := err.(errors.Kinder); ok {
+			errorEntry.Kind = kinded.Kind()
+		}
+		if positionError, ok := err.(interface {
+			positionError
+			Function() string
+		}); ok && positionError.Line() > 0 {
+			errorEntry.File = errors.FormatPath(positionError.File(), errors.PositionFileName)
+			errorEntry.Line = positionError.Line()
+			errorEntry.Function = positionError.Function()
+		}
+		entries = append(entries, errorEntry)
+		if multiError, ok := err.(multipleCausesError); ok {
+			for _, cause := range multiError.Unwrap() {
+				entries = jsonErrorEntries(cause, entries)
+			}
+			return entries
+		}
+	}
+	return entries
+}
+
+// jsonObject is a JSON object with ordered members.
+type jsonObject []Field
+
+// MarshalJSON method converts fields to a JSON object, in fields order. json.Marshaler interface method.
+func (object jsonObject) MarshalJSON() ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	buffer.WriteByte('{')
+	for idx, field := range object {
+		if idx > 0 {
+			buffer.WriteByte(',')
+		}
+		buffer.Write(marshalJSON(field.Key))
+		buffer.WriteByte(':')
+		buffer.Write(marshalJSON(field.Value))
+	}
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
+
+// marshalJSON converts the value to JSON, without HTML escaping.
+// Errors are converted to their one line message (`%v` format): error attributes are never written,
+// sensitive values included. Values which cannot be converted are written as `fmt.Sprint` strings.
+func marshalJSON(value any) []byte {
+	if err, ok := value.(error); ok {
+		value = fmt.Sprintf("%v", err)
+	}
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		buffer.Reset()
+		_ = encoder.Encode(fmt.Sprint(value))
+	}
+	return bytes.TrimSuffix(buffer.Bytes(), []byte("\n"))
+}
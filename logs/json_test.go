@@ -0,0 +1,123 @@
+package logs
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/deverdeb/bvmgo-util/errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJSONFormatter_Format(t *testing.T) {
+	date := time.Date(1982, time.March, 15, 12, 56, 14, 123, time.UTC)
+	err := errors.WithCode(errors.NewWithCause(fmt.Errorf("sql: no rows"), "user not found"), "USR-404")
+	err = errors.With(err, "userId", 42)
+	tests := []struct {
+		name  string
+		names JSONFieldNames
+		entry *Entry
+		want  string
+	}{
+		{
+			name:  "message",
+			names: DefaultJSONFieldNames(),
+			entry: &Entry{Prefix: "users", Level: LevelInfo, File: "/src/user.go", Line: 12, Time: date, Message: "user <bob> loaded"},
+			want:  `{"time":"1982-03-15T12:56:14.000000123Z","level":"INFO","logger":"users","message":"user <bob> loaded","file":"user.go","line":12}`,
+		},
+		{
+			name:  "new lines and invalid UTF-8",
+			names: DefaultJSONFieldNames(),
+			entry: &Entry{Level: LevelWarn, Time: date, Message: "first line\nsecond \"line\" \xff"},
+			want:  `{"time":"1982-03-15T12:56:14.000000123Z","level":"WARN","message":"first line\nsecond \"line\" �"}`,
+		},
+		{
+			name:  "fields",
+			names: DefaultJSONFieldNames(),
+			entry: &Entry{Level: LevelDebug, Time: date, Message: "request",
+				Fields: []Field{{Key: "requestId", Value: "abc"}, {Key: "channel", Value: make(chan int)}, {Key: "cause", Value: fmt.Errorf("timeout")}}},
+			want: `{"time":"1982-03-15T12:56:14.000000123Z","level":"DEBUG","message":"request","fields":{"requestId":"abc","channel":"0x`,
+		},
+		{
+			name:  "custom names",
+			names: JSONFieldNames{Time: "@timestamp", Level: "severity", Message: "msg"},
+			entry: &Entry{Prefix: "users", Level: LevelError, File: "user.go", Line: 12, Time: date, Message: "failed", Fields: []Field{{Key: "k", Value: 1}}},
+			want:  `{"@timestamp":"1982-03-15T12:56:14.000000123Z","severity":"ERROR","msg":"failed"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := (&JSONFormatter{Names: tt.names}).Format(tt.entry)
+			if !json.Valid([]byte(result)) || strings.Contains(result, "\n") {
+				t.Errorf("Format() = '%s', want valid JSON on one line", result)
+			}
+			if !strings.HasPrefix(result, tt.want) {
+				t.Errorf("Format() = '%s', want '%s'", result, tt.want)
+			}
+		})
+	}
+	t.Run("error chain", func(t *testing.T) {
+		result := NewJSONFormatter().Format(&Entry{Level: LevelError, Time: date, Message: "failed", Error: errors.Append(err, fmt.Errorf("other"))})
+		var decoded struct {
+			Errors []jsonErrorEntry `json:"errors"`
+		}
+		if unmarshalErr := json.Unmarshal([]byte(result), &decoded); unmarshalErr != nil {
+			t.Fatalf("Format() = '%s', invalid JSON: %v", result, unmarshalErr)
+		}
+		messages := make([]string, 0, len(decoded.Errors))
+		for _, errorEntry := range decoded.Errors {
+			messages = append(messages, errorEntry.Message)
+		}
+		if got := strings.Join(messages, " | "); got != "2 error(s) occurred | user not found | sql: no rows | other" {
+			t.Errorf("Format() errors = '%s', want aggregated error chain", got)
+		}
+		userNotFound := decoded.Errors[1]
+		if userNotFound.Code != "USR-404" || userNotFound.File != "json_test.go" || userNotFound.Line <= 0 ||
+			!strings.HasSuffix(userNotFound.Function, "TestJSONFormatter_Format") {
+			t.Errorf("Format() error = '%+v', want traceable error position", userNotFound)
+		}
+		result = NewJSONFormatter().Format(&Entry{Level: LevelError, Time: date, Message: "failed", Error: err})
+		if !strings.HasSuffix(result, `"fields":{"userId":42}}`) {
+			t.Errorf("Format() = '%s', want error attributes as fields", result)
+		}
+	})
+}
+
+func ExampleJSONFormatter() {
+	mockBeforeTest()
+	defer restoreAfterTest()
+	logger := New("ExampleJSONFormatter")
+	formatter := NewJSONFormatter()
+	formatter.Names.Time = ""
+	logger.SetFormatter(formatter)
+
+	logger.Infow("user loaded", "userId", 42)
+
+	// Output:
+	// {"level":"INFO","logger":"ExampleJSONFormatter","message":"user loaded","file":"json_test.go","line":94,"fields":{"userId":42}}
+}
+
+func TestJSONFormatter_fields(t *testing.T) {
+	date := time.Date(2023, time.March, 15, 12, 56, 14, 0, time.UTC)
+	err := errors.WithSensitive(errors.With(errors.New("authentication failed"), "userId", 42), "token", "abc123")
+	entry := &Entry{Level: LevelError, Time: date, Message: "failed", Error: err,
+		Fields: []Field{{Key: "userId", Value: 1}, {Key: "requestId", Value: "abc"}, {Key: "userId", Value: 2}}}
+	formatter := NewJSONFormatter()
+	if result := formatter.Format(entry); !strings.HasSuffix(result, `"fields":{"userId":2,"requestId":"abc","token":"[REDACTED]"}}`) {
+		t.Errorf("Format() = '%s', want unique keys and redacted sensitive attributes", result)
+	}
+	EnableSensitiveValues(true)
+	defer EnableSensitiveValues(false)
+	if result := formatter.Format(entry); !strings.HasSuffix(result, `"fields":{"userId":2,"requestId":"abc","token":"abc123"}}`) {
+		t.Errorf("Format() = '%s', want sensitive attributes values", result)
+	}
+}
+
+func TestJSONFormatter_errorField(t *testing.T) {
+	err := errors.WithSensitive(errors.New("authentication failed"), "token", "SECRET")
+	entry := &Entry{Level: LevelError, Message: "failed", Fields: []Field{{Key: "cause", Value: err}}}
+	if result := NewJSONFormatter().Format(entry); strings.Contains(result, "SECRET") ||
+		!strings.Contains(result, `"fields":{"cause":"authentication failed"}`) {
+		t.Errorf("Format() = '%s', want error field as message", result)
+	}
+}
@@ -1,10 +1,12 @@
 package logs
 
 import (
+	goerr "errors"
 	"fmt"
 	"log"
 	"os"
 	"runtime"
+	"syscall"
 	"time"
 )
 
@@ -24,6 +26,8 @@ type Logger struct {
 	formatter Formatter
 	// output is the output destination for the logger
 	output *log.Logger
+	// fields contains the fields written with every message of the logger (see With method)
+	fields []Field
 }
 
 // New method creates a new logger.
@@ -42,6 +46,25 @@ func New(prefix string) *Logger {
 	}
 }
 
+// With returns a child logger with bound fields, written with every message of the child logger.
+// Arguments are alternated keys and values. Child logger has the prefix, level, formatter and output of the logger.
+//
+// Example:
+//
+//	requestLogger := logger.With("requestId", id, "user", user)
+//	requestLogger.Info("request received") // ... request received requestId=42 user=bob
+func (logger *Logger) With(keysAndValues ...any) *Logger {
+	child := *logger
+	child.fields = append(append(make([]Field, 0, len(logger.fields)+len(keysAndValues)/2), logger.fields...),
+		fieldsOf(keysAndValues)...)
+	return &child
+}
+
+// Fields returns the fields bound to the logger (see With method).
+func (logger *Logger) Fields() []Field {
+	return logger.fields
+}
+
 // Prefix returns the output prefix for the logger.
 func (logger *Logger) Prefix() string {
 	return logger.prefix
@@ -80,6 +103,25 @@ func (logger *Logger) SetOutput(output *log.Logger) {
 	logger.output = output
 }
 
+// Flush writes buffered logs of the output destination.
+// The output writer is flushed if it has a `Flush() error` method (bufio.Writer...),
+// then synchronized if it has a `Sync() error` method (os.File...).
+// Synchronization errors of terminals and pipes (not synchronizable) are ignored.
+func (logger *Logger) Flush() error {
+	writer := logger.output.Writer()
+	if flusher, ok := writer.(interface{ Flush() error }); ok {
+		if err := flusher.Flush(); err != nil {
+			return err
+		}
+	}
+	if syncer, ok := writer.(interface{ Sync() error }); ok {
+		if err := syncer.Sync(); err != nil && !goerr.Is(err, syscall.EINVAL) && !goerr.Is(err, syscall.ENOTSUP) {
+			return err
+		}
+	}
+	return nil
+}
+
 // Trace writes message with "Trace" level.
 func (logger *Logger) Trace(attributes ...any) {
 	logger.log(LevelTrace, attributes...)
@@ -90,6 +132,12 @@ func (logger *Logger) Tracef(format string, attributes ...any) {
 	logger.logf(LevelTrace, format, attributes...)
 }
 
+// Tracew writes message with "Trace" level and fields (alternated keys and values).
+// An error after the last key/value pair is the logged error.
+func (logger *Logger) Tracew(message string, keysAndValues ...any) {
+	logger.logw(LevelTrace, message, keysAndValues...)
+}
+
 // Debug writes message with "Debug" level.
 func (logger *Logger) Debug(attributes ...any) {
 	logger.log(LevelDebug, attributes...)
@@ -100,6 +148,12 @@ func (logger *Logger) Debugf(format string, attributes ...any) {
 	logger.logf(LevelDebug, format, attributes...)
 }
 
+// Debugw writes message with "Debug" level and fields (alternated keys and values).
+// An error after the last key/value pair is the logged error.
+func (logger *Logger) Debugw(message string, keysAndValues ...any) {
+	logger.logw(LevelDebug, message, keysAndValues...)
+}
+
 // Info writes message with "Info" level.
 func (logger *Logger) Info(attributes ...any) {
 	logger.log(LevelInfo, attributes...)
@@ -110,6 +164,12 @@ func (logger *Logger) Infof(format string, attributes ...any) {
 	logger.logf(LevelInfo, format, attributes...)
 }
 
+// Infow writes message with "Info" level and fields (alternated keys and values).
+// An error after the last key/value pair is the logged error.
+func (logger *Logger) Infow(message string, keysAndValues ...any) {
+	logger.logw(LevelInfo, message, keysAndValues...)
+}
+
 // Warn writes message with "Warn" level.
 func (logger *Logger) Warn(attributes ...any) {
 	logger.log(LevelWarn, attributes...)
@@ -120,6 +180,12 @@ func (logger *Logger) Warnf(format string, attributes ...any) {
 	logger.logf(LevelWarn, format, attributes...)
 }
 
+// Warnw writes message with "Warn" level and fields (alternated keys and values).
+// An error after the last key/value pair is the logged error.
+func (logger *Logger) Warnw(message string, keysAndValues ...any) {
+	logger.logw(LevelWarn, message, keysAndValues...)
+}
+
 // Error writes message with "Error" level.
 func (logger *Logger) Error(attributes ...any) {
 	logger.log(LevelError, attributes...)
@@ -130,6 +196,12 @@ func (logger *Logger) Errorf(format string, attributes ...any) {
 	logger.logf(LevelError, format, attributes...)
 }
 
+// Errorw writes message with "Error" level and fields (alternated keys and values).
+// An error after the last key/value pair is the logged error.
+func (logger *Logger) Errorw(message string, keysAndValues ...any) {
+	logger.logw(LevelError, message, keysAndValues...)
+}
+
 // Fatal writes message with "Fatal" level.
 // And call os.Exit(1).
 func (logger *Logger) Fatal(attributes ...any) {
@@ -144,13 +216,20 @@ func (logger *Logger) Fatalf(format string, attributes ...any) {
 	exit(1)
 }
 
+// Fatalw writes message with "Fatal" level and fields (alternated keys and values).
+// And call os.Exit(1).
+func (logger *Logger) Fatalw(message string, keysAndValues ...any) {
+	logger.logw(LevelFatal, message, keysAndValues...)
+	exit(1)
+}
+
 // log checks level, formats message and writes to output.
 func (logger *Logger) log(level LogLevel, attributes ...any) {
 	if level >= logger.Level() {
 		file, line := extractFilenameAndLine(2)
 		args, err := extractErrorOfArguments(attributes...)
 		message := fmt.Sprint(args...)
-		logger.output.Print(logger.formatter.Format(logger.prefix, level, file, line, now(), err, message))
+		logger.write(level, file, line, err, message, logger.fields)
 	}
 }
 
@@ -160,10 +239,38 @@ func (logger *Logger) logf(level LogLevel, format string, attributes ...any) {
 		file, line := extractFilenameAndLine(2)
 		args, err := extractErrorOfArguments(attributes...)
 		message := fmt.Sprintf(format, args...)
-		logger.output.Print(logger.formatter.Format(logger.prefix, level, file, line, now(), err, message))
+		logger.write(level, file, line, err, message, logger.fields)
+	}
+}
+
+// logw checks level, formats message with fields and writes to output.
+func (logger *Logger) logw(level LogLevel, message string, keysAndValues ...any) {
+	if level >= logger.Level() {
+		file, line := extractFilenameAndLine(2)
+		var err error
+		if len(keysAndValues)%2 == 1 {
+			keysAndValues, err = extractErrorOfArguments(keysAndValues...)
+		}
+		fields := append(append(make([]Field, 0, len(logger.fields)+len(keysAndValues)/2), logger.fields...),
+			fieldsOf(keysAndValues)...)
+		logger.write(level, file, line, err, message, fields)
 	}
 }
 
+// write formats the log entry and writes it to output.
+func (logger *Logger) write(level LogLevel, file string, line int, err error, message string, fields []Field) {
+	logger.output.Print(logger.formatter.Format(&Entry{
+		Prefix:  logger.prefix,
+		Level:   level,
+		File:    file,
+		Line:    line,
+		Time:    now(),
+		Error:   err,
+		Message: message,
+		Fields:  fields,
+	}))
+}
+
 // extractFilenameAndLine returns the log position in code (filename and line).
 // The argument skip is the number of stack frames to ascend, with 0 identifying the caller of extractFilenameAndLine.
 func extractFilenameAndLine(skip int) (filename string, line int) {
@@ -10,14 +10,13 @@ import (
 	"strings"
 )
 
-func ReadFromFileToMap(filename string) (map[string]string, error) {
+func ReadFromFileToMap(filename string) (properties map[string]string, err error) {
 	propFile, err := os.Open(filename)
 	if err != nil {
 		return nil, errors.NewWithCause(err, "failed to open '%s' properties file", filename)
 	}
-	defer closeFile(propFile)
-	properties, err := ReadToMap(propFile)
-	return properties, err
+	defer errors.CloseAndJoin(&err, propFile)
+	return ReadToMap(propFile)
 }
 
 func ReadFromBytesToMap(content []byte) (map[string]string, error) {
@@ -63,9 +62,3 @@ func extractDataFromLine(lineReader *iostring.LineReader) (key string, value str
 		return key, value, true, nil
 	}
 }
-
-func closeFile(file *os.File) {
-	if file != nil {
-		_ = file.Close()
-	}
-}
@@ -1,5 +1,10 @@
 package term
 
+import (
+	"io"
+	"os"
+)
+
 // Color is a terminal color.
 type Color string
 
@@ -49,3 +54,19 @@ func (color Color) String() string {
 func (color Color) Sprint(message string) string {
 	return color.String() + message + Reset.String()
 }
+
+// ColorEnabled returns false if terminal colors are disabled (`NO_COLOR` environment variable, or "dumb" terminal).
+func ColorEnabled() bool {
+	_, noColor := os.LookupEnv("NO_COLOR")
+	return !noColor && os.Getenv("TERM") != "dumb"
+}
+
+// IsTerminal returns true if the output is a terminal (character device file, example: os.Stderr not redirected).
+func IsTerminal(output io.Writer) bool {
+	file, ok := output.(*os.File)
+	if !ok || file == nil {
+		return false
+	}
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -42,8 +42,7 @@ func DisplayInformations() {
 	fmt.Printf("   - Command line arguments: %s\n", argsWithoutProg)
 
 	fmt.Printf(White.Sprint("Terminal information:\n"))
-	_, exists := os.LookupEnv("NO_COLOR")
-	nocolor := exists || os.Getenv("TERM") == "dumb"
+	nocolor := !ColorEnabled()
 	fmt.Printf("   - No color: #{color} %v\n", nocolor)
 
 }